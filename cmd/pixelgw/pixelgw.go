@@ -1,16 +1,44 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+	_ "time/tzdata" // Channel time zones in minimal container images
 
 	"github.com/joe714/pixelgw/internal/api"
 	"github.com/joe714/pixelgw/internal/durable"
 	"github.com/joe714/pixelgw/internal/hub"
+	"github.com/joe714/pixelgw/internal/tidbyt"
 	"tidbyt.dev/pixlet/runtime"
 )
 
 func main() {
+	cfg := hub.Config{}
+	flag.DurationVar(&cfg.IdleTimeout, "idle-timeout", time.Minute,
+		"Stop rendering channels with no subscribers after this long, 0 to never stop")
+	flag.DurationVar(&cfg.StallTimeout, "stall-timeout", time.Minute,
+		"Disconnect devices that have not accepted a frame for this long, 0 for the 10s write deadline only")
+	flag.DurationVar(&cfg.RenderTimeout, "render-timeout", 10*time.Second,
+		"Abandon applets that run longer than this, unless the applet sets its own timeout")
+	flag.IntVar(&cfg.QuarantineAfter, "quarantine-after", 10,
+		"Stop running applets after this many consecutive failures, 0 to keep retrying")
+	flag.StringVar(&cfg.Enrollment, "enrollment", durable.EnrollOpen,
+		"What to do with devices the server hasn't seen before: open adds them to the default channel, "+
+			"approval holds them until approved, closed refuses them")
+	flag.IntVar(&cfg.MaxAnonymous, "max-anonymous", 16,
+		"Most anonymous channel viewers connected at once, 0 for no limit")
+	flag.Parse()
+	switch cfg.Enrollment {
+	case durable.EnrollOpen, durable.EnrollApproval, durable.EnrollClosed:
+	default:
+		log.Fatalf("Unknown enrollment policy %q", cfg.Enrollment)
+	}
+
 	runtime.InitCache(runtime.NewInMemoryCache())
 	fs := http.FileServer(http.Dir("./static"))
 
@@ -19,7 +47,20 @@ func main() {
 		log.Fatal(err)
 	}
 
-	hub := hub.NewHub(store)
+	hub := hub.NewHub(store, cfg)
+
+	// SIGHUP rescans the applet directories
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			log.Println("Reloading applet catalog")
+			err := hub.ReloadCatalog()
+			if err != nil {
+				log.Printf("Catalog reload failed: %v\n", err)
+			}
+		}
+	}()
 
 	svr := api.NewServer(hub, store)
 
@@ -29,6 +70,7 @@ func main() {
 	root.HandleFunc("/ws", hub.GetWsHandler())
 	hdlr := api.NewStrictHandlerWithOptions(svr, nil, api.ServerOptions())
 	api.HandlerFromMuxWithBaseURL(hdlr, root, "/api")
+	tidbyt.NewHandler(hub, store).Register(root)
 
 	s := &http.Server{
 		Handler: root,
@@ -5,22 +5,12 @@ package api
 import (
 	"context"
 	"fmt"
-	"log"
-	"slices"
-
-	"tidbyt.dev/pixlet/runtime"
 )
 
 func (s *Server) GetApplets(ctx context.Context, request GetAppletsRequestObject) (GetAppletsResponseObject, error) {
 	var resp []App
 
-	keys := make([]string, 0)
-	for k, _ := range s.hub.Catalog.Manifests {
-		keys = append(keys, k)
-	}
-	slices.Sort(keys)
-	for _, k := range keys {
-		m := s.hub.Catalog.FindManifest(k)
+	for _, m := range s.hub.Catalog.List() {
 		a := App{Id: m.ID, Name: m.Name, Summary: m.Summary, Description: m.Desc, Author: m.Author}
 		resp = append(resp, a)
 	}
@@ -34,8 +24,7 @@ func (s *Server) GetAppletByID(ctx context.Context, request GetAppletByIDRequest
 	}
 	resp := App{Id: m.ID, Name: m.Name, Summary: m.Summary, Description: m.Desc, Author: m.Author}
 
-	log.Printf("Load Applet %v", m.ID)
-	app, err := runtime.NewAppletFromFS(m.ID, m.Bundle)
+	app, err := s.hub.Catalog.LoadApplet(m)
 	if err != nil {
 		return nil, err
 	}
@@ -6,10 +6,14 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"slices"
 
 	"github.com/google/uuid"
 
 	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/errors"
+	"github.com/joe714/pixelgw/internal/hub"
+	"github.com/joe714/pixelgw/internal/schedule"
 )
 
 func (s *Server) GetChannels(ctx context.Context, request GetChannelsRequestObject) (GetChannelsResponseObject, error) {
@@ -24,17 +28,38 @@ func (s *Server) GetChannels(ctx context.Context, request GetChannelsRequestObje
 
 	resp := make([]ChannelSummary, 0, len(ch))
 	for _, c := range ch {
-		resp = append(resp, ChannelSummary{
-			UUID:    &c.UUID,
-			Name:    c.Name,
-			Comment: c.Comment,
-		})
+		resp = append(resp, renderChannelSummary(&c))
 	}
 	return GetChannels200JSONResponse(resp), nil
 }
 
 func (s *Server) CreateChannel(ctx context.Context, request CreateChannelRequestObject) (CreateChannelResponseObject, error) {
-	ch, err := s.store.CreateChannel(ctx, request.Body.Name, request.Body.Comment)
+	ch := durable.Channel{
+		Name:          request.Body.Name,
+		Comment:       request.Body.Comment,
+		DisplayTime:   request.Body.DisplayTime,
+		FallbackAppID: request.Body.FallbackAppID,
+	}
+	if request.Body.FitAnimation != nil {
+		ch.FitAnimation = *request.Body.FitAnimation
+	}
+	if request.Body.Anonymous != nil {
+		ch.Anonymous = *request.Body.Anonymous
+	}
+	if request.Body.Fallback != nil {
+		ch.Fallback = *request.Body.Fallback
+	}
+	if request.Body.FallbackConfig != nil {
+		cfg := string(request.Body.FallbackConfig)
+		ch.FallbackConfig = &cfg
+	}
+	if request.Body.Location != nil {
+		applyLocation(&ch, request.Body.Location)
+	}
+	err := s.checkFallback(&ch)
+	if err == nil {
+		err = s.store.CreateChannel(ctx, &ch)
+	}
 	if err != nil {
 		return CreateChanneldefaultJSONResponse{
 				Body:       RenderError(err),
@@ -42,13 +67,7 @@ func (s *Server) CreateChannel(ctx context.Context, request CreateChannelRequest
 			},
 			nil
 	}
-	return CreateChannel201JSONResponse(
-			ChannelDetail{
-				UUID:    &ch.UUID,
-				Name:    ch.Name,
-				Comment: ch.Comment,
-			}),
-		nil
+	return CreateChannel201JSONResponse(renderChannel(&ch)), nil
 }
 
 func (s *Server) FindChannelByUUID(ctx context.Context, request FindChannelByUUIDRequestObject) (FindChannelByUUIDResponseObject, error) {
@@ -61,21 +80,13 @@ func (s *Server) FindChannelByUUID(ctx context.Context, request FindChannelByUUI
 			nil
 	}
 
-	cd := ChannelDetail{
-		UUID:    &ch.UUID,
-		Name:    ch.Name,
-		Comment: ch.Comment,
-	}
+	cd := renderChannel(ch)
 
 	apps := make([]AppInstanceDetail, 0, len(ch.Applets))
 	for _, i := range ch.Applets {
-		a := AppInstanceDetail{
-			UUID:  &i.UUID,
-			Idx:   &i.Idx,
-			AppID: i.AppID,
-		}
-		if i.Config != nil {
-			a.Config = json.RawMessage(*i.Config)
+		a := renderAppInstance(&i)
+		if h, ok := s.hub.AppletHealth(i.UUID); ok {
+			a.Status = renderAppletStatus(h)
 		}
 		apps = append(apps, a)
 	}
@@ -96,10 +107,193 @@ func (s *Server) FindChannelByUUID(ctx context.Context, request FindChannelByUUI
 	return FindChannelByUUID200JSONResponse(cd), nil
 }
 
+func (s *Server) DeleteChannel(ctx context.Context, request DeleteChannelRequestObject) (DeleteChannelResponseObject, error) {
+	err := s.store.DeleteChannel(ctx, request.UUID)
+	if err != nil {
+		return DeleteChanneldefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	s.hub.DeleteChannel(request.UUID)
+	return DeleteChannel200Response{}, nil
+}
+
+func (s *Server) PatchChannel(ctx context.Context, request PatchChannelRequestObject) (PatchChannelResponseObject, error) {
+	ch, err := s.store.GetChannelByUUID(ctx, request.UUID)
+	if err != nil {
+		return PatchChanneldefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+
+	if request.Body.Name != nil {
+		ch.Name = *request.Body.Name
+	}
+	if request.Body.Comment != nil {
+		ch.Comment = request.Body.Comment
+	}
+	reload := false
+	if request.Body.DisplayTime != nil {
+		reload = true
+		ch.DisplayTime = request.Body.DisplayTime
+		if *ch.DisplayTime == 0 {
+			ch.DisplayTime = nil
+		}
+	}
+	if request.Body.FitAnimation != nil {
+		reload = true
+		ch.FitAnimation = *request.Body.FitAnimation
+	}
+	if request.Body.Fallback != nil {
+		reload = true
+		ch.Fallback = *request.Body.Fallback
+	}
+	if request.Body.FallbackAppID != nil {
+		reload = true
+		ch.FallbackAppID = request.Body.FallbackAppID
+	}
+	if request.Body.FallbackConfig != nil {
+		reload = true
+		cfg := string(request.Body.FallbackConfig)
+		ch.FallbackConfig = &cfg
+	}
+	if request.Body.Location != nil {
+		reload = true
+		applyLocation(ch, request.Body.Location)
+	}
+	if request.Body.Anonymous != nil {
+		ch.Anonymous = *request.Body.Anonymous
+	}
+
+	err = s.checkFallback(ch)
+	if err == nil {
+		err = s.store.ModifyChannel(ctx, ch)
+	}
+	if err != nil {
+		return PatchChanneldefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+
+	s.hub.RenameChannel(ch.UUID, ch.Name)
+	if reload {
+		s.hub.ReloadApplets(ch.UUID, uuid.Nil)
+	}
+	if !ch.Anonymous {
+		s.hub.DisconnectAnonymous(ch.UUID)
+	}
+	return PatchChannel200Response{}, nil
+}
+
+// checkFallback verifies a fallback applet is in the catalog.
+func (s *Server) checkFallback(ch *durable.Channel) error {
+	if ch.Fallback != durable.FallbackApplet || ch.FallbackAppID == nil {
+		return nil
+	}
+	if s.hub.Catalog.FindManifest(*ch.FallbackAppID) == nil {
+		return errors.Wrap(errors.InvalidFallback, "fallback applet %v not found", *ch.FallbackAppID)
+	}
+	return nil
+}
+
+// applyLocation replaces the channel's location with loc.
+func applyLocation(ch *durable.Channel, loc *ChannelLocation) {
+	ch.Timezone = loc.Timezone
+	if ch.Timezone != nil && *ch.Timezone == "" {
+		ch.Timezone = nil
+	}
+	ch.Latitude = loc.Latitude
+	ch.Longitude = loc.Longitude
+}
+
+func renderLocation(ch *durable.Channel) *ChannelLocation {
+	if ch.Timezone == nil && ch.Latitude == nil && ch.Longitude == nil {
+		return nil
+	}
+	return &ChannelLocation{
+		Timezone:  ch.Timezone,
+		Latitude:  ch.Latitude,
+		Longitude: ch.Longitude,
+	}
+}
+
+// encodeSchedule converts a schedule to its stored form. An empty
+// schedule is stored as an empty string.
+func encodeSchedule(sched *AppletSchedule) (*string, error) {
+	if sched == nil {
+		return nil, nil
+	}
+	tmp := ""
+	if !sched.IsEmpty() {
+		data, err := json.Marshal(sched)
+		if err != nil {
+			return nil, errors.Wrap(errors.InvalidSchedule, "invalid schedule: %v", err)
+		}
+		tmp = string(data)
+	}
+	return &tmp, nil
+}
+
+func renderSchedule(data *string) *AppletSchedule {
+	if data == nil {
+		return nil
+	}
+	sched, err := schedule.Parse(*data)
+	if err != nil {
+		return nil
+	}
+	return sched
+}
+
+func renderChannelSummary(ch *durable.Channel) ChannelSummary {
+	cs := ChannelSummary{
+		UUID:          &ch.UUID,
+		Name:          ch.Name,
+		Comment:       ch.Comment,
+		DisplayTime:   ch.DisplayTime,
+		FitAnimation:  &ch.FitAnimation,
+		Fallback:      &ch.Fallback,
+		FallbackAppID: ch.FallbackAppID,
+		Location:      renderLocation(ch),
+		Anonymous:     &ch.Anonymous,
+	}
+	if ch.FallbackConfig != nil {
+		cs.FallbackConfig = json.RawMessage(*ch.FallbackConfig)
+	}
+	return cs
+}
+
+func renderChannel(ch *durable.Channel) ChannelDetail {
+	cd := ChannelDetail{
+		UUID:          &ch.UUID,
+		Name:          ch.Name,
+		Comment:       ch.Comment,
+		DisplayTime:   ch.DisplayTime,
+		FitAnimation:  &ch.FitAnimation,
+		Fallback:      &ch.Fallback,
+		FallbackAppID: ch.FallbackAppID,
+		Location:      renderLocation(ch),
+		Anonymous:     &ch.Anonymous,
+	}
+	if ch.FallbackConfig != nil {
+		cd.FallbackConfig = json.RawMessage(*ch.FallbackConfig)
+	}
+	return cd
+}
+
 func (s *Server) CreateChannelApplet(ctx context.Context, request CreateChannelAppletRequestObject) (CreateChannelAppletResponseObject, error) {
 	app := durable.ChannelApplet{
-		Idx:   -1,
-		AppID: request.Body.AppID,
+		Idx:             -1,
+		AppID:           request.Body.AppID,
+		DisplayTime:     request.Body.DisplayTime,
+		RenderTimeout:   request.Body.RenderTimeout,
+		RefreshInterval: request.Body.RefreshInterval,
 	}
 
 	if request.Body.Idx != nil {
@@ -120,8 +314,15 @@ func (s *Server) CreateChannelApplet(ctx context.Context, request CreateChannelA
 		app.Config = &cfg
 	}
 
+	sched, err := encodeSchedule(request.Body.Schedule)
+	if sched != nil && *sched != "" {
+		app.Schedule = sched
+	}
+
 	// TODO: Verify schema
-	err := s.store.CreateChannelApplet(ctx, request.ChannelUUID, &app)
+	if err == nil {
+		err = s.store.CreateChannelApplet(ctx, request.ChannelUUID, &app)
+	}
 	if err != nil {
 		return CreateChannelAppletdefaultJSONResponse{
 				Body:       RenderError(err),
@@ -132,10 +333,14 @@ func (s *Server) CreateChannelApplet(ctx context.Context, request CreateChannelA
 
 	s.hub.ReloadApplets(request.ChannelUUID, app.UUID)
 	return CreateChannelApplet201JSONResponse{
-			AppID:  app.AppID,
-			Config: request.Body.Config,
-			Idx:    &app.Idx,
-			UUID:   &app.UUID,
+			AppID:           app.AppID,
+			Config:          request.Body.Config,
+			Idx:             &app.Idx,
+			UUID:            &app.UUID,
+			DisplayTime:     app.DisplayTime,
+			RenderTimeout:   app.RenderTimeout,
+			RefreshInterval: app.RefreshInterval,
+			Schedule:        renderSchedule(app.Schedule),
 		},
 		nil
 }
@@ -154,13 +359,21 @@ func (s *Server) DeleteChannelApplet(ctx context.Context, request DeleteChannelA
 }
 
 func (s *Server) PatchChannelApplet(ctx context.Context, request PatchChannelAppletRequestObject) (PatchChannelAppletResponseObject, error) {
-	idx := request.Body.Idx
-	var cfg *string
+	patch := durable.ChannelAppletPatch{
+		Idx:             request.Body.Idx,
+		DisplayTime:     request.Body.DisplayTime,
+		RenderTimeout:   request.Body.RenderTimeout,
+		RefreshInterval: request.Body.RefreshInterval,
+	}
 	if request.Body.Config != nil {
 		tmp := string(request.Body.Config)
-		cfg = &tmp
+		patch.Config = &tmp
+	}
+	sched, err := encodeSchedule(request.Body.Schedule)
+	patch.Schedule = sched
+	if err == nil {
+		err = s.store.ModifyChannelApplet(ctx, request.ChannelUUID, request.AppletUUID, &patch)
 	}
-	err := s.store.ModifyChannelApplet(ctx, request.ChannelUUID, request.AppletUUID, idx, cfg)
 	if err != nil {
 		return PatchChannelAppletdefaultJSONResponse{
 				Body:       RenderError(err),
@@ -171,3 +384,93 @@ func (s *Server) PatchChannelApplet(ctx context.Context, request PatchChannelApp
 	s.hub.ReloadApplets(request.ChannelUUID, request.AppletUUID)
 	return PatchChannelApplet200Response{}, nil
 }
+
+func (s *Server) ClearAppletQuarantine(ctx context.Context, request ClearAppletQuarantineRequestObject) (ClearAppletQuarantineResponseObject, error) {
+	ch, err := s.store.GetChannelByUUID(ctx, request.ChannelUUID)
+	if err == nil && !slices.ContainsFunc(ch.Applets, func(a durable.ChannelApplet) bool {
+		return a.UUID == request.AppletUUID
+	}) {
+		err = errors.AppletNotFound
+	}
+	if err != nil {
+		return ClearAppletQuarantinedefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	s.hub.ClearQuarantine(request.AppletUUID)
+	return ClearAppletQuarantine200Response{}, nil
+}
+
+func (s *Server) PutChannelInstallation(ctx context.Context, request PutChannelInstallationRequestObject) (PutChannelInstallationResponseObject, error) {
+	idx := -1
+	if request.Body.Idx != nil {
+		idx = *request.Body.Idx
+	}
+	img, err := hub.NoticeImage(request.Body.Image)
+	if err != nil {
+		err = errors.Wrap(errors.InvalidImage, "%v", err)
+	}
+	var app *durable.ChannelApplet
+	created := false
+	if err == nil {
+		app, created, err = s.store.PutChannelImage(ctx, request.ChannelUUID, request.InstallationID, img, idx)
+	}
+	if err != nil {
+		return PutChannelInstallationdefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+
+	if created {
+		s.hub.ReloadApplets(request.ChannelUUID, app.UUID)
+		return PutChannelInstallation201JSONResponse(renderAppInstance(app)), nil
+	}
+	if request.Body.Idx != nil {
+		s.hub.ReloadApplets(request.ChannelUUID, app.UUID)
+	} else {
+		s.hub.UpdateImage(request.ChannelUUID, app.UUID, img)
+	}
+	return PutChannelInstallation200JSONResponse(renderAppInstance(app)), nil
+}
+
+func renderAppInstance(i *durable.ChannelApplet) AppInstanceDetail {
+	a := AppInstanceDetail{
+		UUID:            &i.UUID,
+		Idx:             &i.Idx,
+		Kind:            &i.Kind,
+		AppID:           i.AppID,
+		InstallationID:  i.InstallationID,
+		DisplayTime:     i.DisplayTime,
+		RenderTimeout:   i.RenderTimeout,
+		RefreshInterval: i.RefreshInterval,
+		Schedule:        renderSchedule(i.Schedule),
+	}
+	if i.Config != nil {
+		a.Config = json.RawMessage(*i.Config)
+	}
+	return a
+}
+
+func renderAppletStatus(h hub.AppletHealth) *AppletStatus {
+	st := AppletStatus{
+		Failures:    &h.Failures,
+		Quarantined: &h.Quarantined,
+	}
+	if h.LastError != "" {
+		st.LastError = &h.LastError
+	}
+	if !h.LastFailure.IsZero() {
+		st.LastFailure = &h.LastFailure
+	}
+	if !h.LastSuccess.IsZero() {
+		st.LastSuccess = &h.LastSuccess
+	}
+	if !h.RetryAt.IsZero() {
+		st.RetryAt = &h.RetryAt
+	}
+	return &st
+}
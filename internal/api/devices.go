@@ -2,9 +2,15 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/errors"
+	"github.com/joe714/pixelgw/internal/hub"
 )
 
 func (s *Server) GetDevices(ctx context.Context, request GetDevicesRequestObject) (GetDevicesResponseObject, error) {
@@ -16,16 +22,13 @@ func (s *Server) GetDevices(ctx context.Context, request GetDevicesRequestObject
 			},
 			nil
 	}
+	live := s.liveDevices()
 	resp := make([]DeviceSummary, 0, len(devs))
 	for _, d := range devs {
-		resp = append(resp, DeviceSummary{
-			UUID: &d.UUID,
-			Name: &d.Name,
-			Channel: &ChannelRef{
-				UUID: &d.ChannelUUID,
-				Name: d.ChannelName,
-			},
-		})
+		if request.Params.State != nil && d.State != *request.Params.State {
+			continue
+		}
+		resp = append(resp, renderDevice(&d, live))
 	}
 	return GetDevices200JSONResponse(resp), nil
 }
@@ -39,19 +42,89 @@ func (s *Server) GetDeviceByUUID(ctx context.Context, request GetDeviceByUUIDReq
 			},
 			nil
 	}
-	resp := DeviceSummary{
+	return GetDeviceByUUID200JSONResponse(renderDevice(d, s.liveDevices())), nil
+}
+
+// liveDevices returns when the oldest live session of each connected
+// device started.
+func (s *Server) liveDevices() map[uuid.UUID]time.Time {
+	live := make(map[uuid.UUID]time.Time)
+	for _, si := range s.hub.GetSessions() {
+		if t, ok := live[si.DeviceUUID]; !ok || si.Connected.Before(t) {
+			live[si.DeviceUUID] = si.Connected
+		}
+	}
+	return live
+}
+
+func renderDevice(d *durable.Device, live map[uuid.UUID]time.Time) DeviceSummary {
+	ds := DeviceSummary{
 		UUID: &d.UUID,
 		Name: &d.Name,
 		Channel: &ChannelRef{
 			UUID: &d.ChannelUUID,
 			Name: d.ChannelName,
 		},
+		Format:        d.Format,
+		Brightness:    d.Brightness,
+		Dimming:       renderDimming(d.Dimming),
+		LastAddr:      d.LastIP,
+		State:         &d.State,
+		PairingCode:   d.PairingCode,
+		TokenRequired: &d.TokenRequired,
+	}
+	if d.LastTime != nil {
+		ds.LastSeen = &d.LastTime.Time
+	}
+	if d.TokenIssued != nil {
+		ds.TokenIssued = &d.TokenIssued.Time
+	}
+	since, online := live[d.UUID]
+	ds.Online = &online
+	if online {
+		uptime := int(time.Since(since).Seconds())
+		ds.ConnectedSince = &since
+		ds.Uptime = &uptime
 	}
-	return GetDeviceByUUID200JSONResponse(resp), nil
+	return ds
+}
+
+func (s *Server) GetDeviceConnections(ctx context.Context, request GetDeviceConnectionsRequestObject) (GetDeviceConnectionsResponseObject, error) {
+	_, err := s.store.GetDeviceByUUID(ctx, request.DeviceUUID)
+	var conns []durable.DeviceConnection
+	if err == nil {
+		conns, err = s.store.GetDeviceConnections(ctx, request.DeviceUUID)
+	}
+	if err != nil {
+		return GetDeviceConnectionsdefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	resp := make([]DeviceConnection, 0, len(conns))
+	for _, c := range conns {
+		dc := DeviceConnection{
+			ID:         &c.UUID,
+			SessionID:  &c.SessionID,
+			RemoteAddr: &c.RemoteAddr,
+			Connected:  &c.Connected.Time,
+			Frames:     &c.Frames,
+		}
+		if c.Disconnected != nil {
+			dc.Disconnected = &c.Disconnected.Time
+		}
+		if c.LastFrame != nil {
+			dc.LastFrame = &c.LastFrame.Time
+		}
+		resp = append(resp, dc)
+	}
+	return GetDeviceConnections200JSONResponse(resp), nil
 }
 
 func (s *Server) PatchDevice(ctx context.Context, request PatchDeviceRequestObject) (PatchDeviceResponseObject, error) {
-	if request.Body.Name == nil && request.Body.Channel == nil {
+	body := request.Body
+	if body.Name == nil && body.Channel == nil && body.Format == nil && body.Brightness == nil && body.Dimming == nil {
 		return PatchDevicedefaultJSONResponse{
 				Body: Error{
 					Code:    http.StatusBadRequest,
@@ -74,6 +147,31 @@ func (s *Server) PatchDevice(ctx context.Context, request PatchDeviceRequestObje
 	if request.Body.Name != nil {
 		d.Name = *request.Body.Name
 	}
+	if f := request.Body.Format; f != nil {
+		if *f != "" && !hub.ValidFormat(*f) {
+			err = errors.Wrap(errors.InvalidFormat, "unsupported image format %q", *f)
+			return PatchDevicedefaultJSONResponse{
+					Body:       RenderError(err),
+					StatusCode: StatusCode(err),
+				},
+				nil
+		}
+		d.Format = f
+		if *f == "" {
+			d.Format = nil
+		}
+	}
+	if body.Brightness != nil {
+		d.Brightness = body.Brightness
+	}
+	if body.Dimming != nil {
+		d.Dimming = nil
+		if len(*body.Dimming) > 0 {
+			data, _ := json.Marshal(*body.Dimming)
+			tmp := string(data)
+			d.Dimming = &tmp
+		}
+	}
 
 	subscribe := false
 	if request.Body.Channel != nil {
@@ -125,5 +223,127 @@ func (s *Server) PatchDevice(ctx context.Context, request PatchDeviceRequestObje
 	if subscribe {
 		s.hub.SubscribeDevice(d.UUID, d.ChannelUUID)
 	}
+	if subscribe || body.Brightness != nil || body.Dimming != nil {
+		s.hub.SetDeviceBrightness(d)
+	}
 	return PatchDevice200Response{}, nil
 }
+
+func renderDimming(data *string) *[]durable.DimmingRule {
+	if data == nil {
+		return nil
+	}
+	rules, err := durable.ParseDimming(*data)
+	if err != nil {
+		return nil
+	}
+	return &rules
+}
+
+func (s *Server) SendDeviceCommand(ctx context.Context, request SendDeviceCommandRequestObject) (SendDeviceCommandResponseObject, error) {
+	_, err := s.store.GetDeviceByUUID(ctx, request.DeviceUUID)
+	var msg hub.Message
+	if err == nil {
+		switch request.Body.Command {
+		case hub.MsgBrightness:
+			b := request.Body.Brightness
+			if b == nil || *b < 0 || *b > 100 {
+				err = errors.Wrap(errors.InvalidCommand, "brightness must be between 0 and 100")
+			}
+			msg = hub.Message{Type: hub.MsgBrightness, Brightness: b}
+		case hub.MsgReboot:
+			msg = hub.Message{Type: hub.MsgReboot}
+		default:
+			err = errors.Wrap(errors.InvalidCommand, "unknown command %q", request.Body.Command)
+		}
+	}
+	if err == nil && s.hub.SendCommand(request.DeviceUUID, &msg) == 0 {
+		err = errors.Wrap(errors.DeviceNotConnected, "device not connected or doesn't accept commands")
+	}
+	if err != nil {
+		return SendDeviceCommanddefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	return SendDeviceCommand202Response{}, nil
+}
+
+func (s *Server) ApproveDevice(ctx context.Context, request ApproveDeviceRequestObject) (ApproveDeviceResponseObject, error) {
+	err := s.store.SetDeviceState(ctx, request.DeviceUUID, durable.DeviceApproved)
+	var d *durable.Device
+	if err == nil {
+		d, err = s.store.GetDeviceByUUID(ctx, request.DeviceUUID)
+	}
+	if err == nil {
+		err = s.hub.ApproveDevice(d.UUID, d.ChannelUUID)
+	}
+	if err != nil {
+		return ApproveDevicedefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	return ApproveDevice200Response{}, nil
+}
+
+func (s *Server) RejectDevice(ctx context.Context, request RejectDeviceRequestObject) (RejectDeviceResponseObject, error) {
+	err := s.store.SetDeviceState(ctx, request.DeviceUUID, durable.DeviceRejected)
+	if err != nil {
+		return RejectDevicedefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	s.hub.DisconnectDevice(request.DeviceUUID)
+	return RejectDevice200Response{}, nil
+}
+
+func (s *Server) RotateDeviceToken(ctx context.Context, request RotateDeviceTokenRequestObject) (RotateDeviceTokenResponseObject, error) {
+	token, hash, err := durable.NewDeviceToken()
+	if err == nil {
+		_, err = s.store.SetDeviceToken(ctx, request.DeviceUUID, hash, false)
+	}
+	if err != nil {
+		return RotateDeviceTokendefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	s.hub.SendToken(request.DeviceUUID, token)
+	return RotateDeviceToken201JSONResponse{Token: token}, nil
+}
+
+func (s *Server) RevokeDeviceToken(ctx context.Context, request RevokeDeviceTokenRequestObject) (RevokeDeviceTokenResponseObject, error) {
+	err := s.store.RevokeDeviceToken(ctx, request.DeviceUUID)
+	if err != nil {
+		return RevokeDeviceTokendefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	s.hub.DisconnectDevice(request.DeviceUUID)
+	return RevokeDeviceToken200Response{}, nil
+}
+
+func (s *Server) DeleteDevice(ctx context.Context, request DeleteDeviceRequestObject) (DeleteDeviceResponseObject, error) {
+	var block time.Duration
+	if request.Params.Block != nil {
+		block = time.Duration(*request.Params.Block) * time.Second
+	}
+	err := s.store.DeleteDevice(ctx, request.UUID, block)
+	if err != nil {
+		return DeleteDevicedefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	s.hub.DisconnectDevice(request.UUID)
+	return DeleteDevice200Response{}, nil
+}
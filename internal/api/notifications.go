@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/joe714/pixelgw/internal/errors"
+	"github.com/joe714/pixelgw/internal/hub"
+)
+
+func (s *Server) NotifyChannel(ctx context.Context, request NotifyChannelRequestObject) (NotifyChannelResponseObject, error) {
+	_, err := s.store.GetChannelByUUID(ctx, request.ChannelUUID)
+	var n *hub.Notice
+	if err == nil {
+		n, err = s.buildNotice(ctx, request.Body)
+	}
+	if err == nil {
+		err = s.hub.NotifyChannel(request.ChannelUUID, n)
+	}
+	if err != nil {
+		return NotifyChanneldefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	return NotifyChannel202Response{}, nil
+}
+
+func (s *Server) NotifyDevice(ctx context.Context, request NotifyDeviceRequestObject) (NotifyDeviceResponseObject, error) {
+	_, err := s.store.GetDeviceByUUID(ctx, request.DeviceUUID)
+	// Don't bother rendering for a device that isn't there
+	if err == nil && !s.hub.DeviceConnected(request.DeviceUUID) {
+		err = errors.DeviceNotConnected
+	}
+	var n *hub.Notice
+	if err == nil {
+		n, err = s.buildNotice(ctx, request.Body)
+	}
+	if err == nil && !s.hub.NotifyDevice(request.DeviceUUID, n) {
+		// Disconnected while rendering
+		err = errors.DeviceNotConnected
+	}
+	if err != nil {
+		return NotifyDevicedefaultJSONResponse{
+				Body:       RenderError(err),
+				StatusCode: StatusCode(err),
+			},
+			nil
+	}
+	return NotifyDevice202Response{}, nil
+}
+
+// buildNotice decodes an uploaded image or renders an applet for a
+// notification.
+func (s *Server) buildNotice(ctx context.Context, body *NotificationRequest) (*hub.Notice, error) {
+	if (body.Image == nil) == (body.AppID == nil) {
+		return nil, errors.Wrap(errors.InvalidNotification, "exactly one of image or app-id is required")
+	}
+	if body.Duration != nil && *body.Duration < 0 {
+		return nil, errors.Wrap(errors.InvalidNotification, "duration must not be negative")
+	}
+	if body.Repeat != nil && *body.Repeat < 0 {
+		return nil, errors.Wrap(errors.InvalidNotification, "repeat must not be negative")
+	}
+
+	var img []byte
+	var err error
+	name := "upload"
+	if body.Image != nil {
+		img, err = hub.NoticeImage(*body.Image)
+		if err != nil {
+			return nil, errors.Wrap(errors.InvalidImage, "%v", err)
+		}
+	} else {
+		m := s.hub.Catalog.FindManifest(*body.AppID)
+		if m == nil {
+			return nil, errors.Wrap(errors.AppletNotFound, "applet %v not found", *body.AppID)
+		}
+		name = m.ID
+		cfg := make(map[string]string)
+		if body.Config != nil {
+			err = json.Unmarshal(body.Config, &cfg)
+			if err != nil {
+				return nil, errors.Wrap(errors.InvalidNotification, "invalid config: %v", err)
+			}
+		}
+		img, err = s.hub.RenderNotice(ctx, m, cfg)
+		if err != nil {
+			return nil, errors.Wrap(errors.InvalidNotification, "%v %v", m.ID, err)
+		}
+	}
+
+	if body.Name != nil {
+		name = *body.Name
+	}
+	var priority, repeat int
+	var duration time.Duration
+	if body.Priority != nil {
+		priority = *body.Priority
+	}
+	if body.Repeat != nil {
+		repeat = *body.Repeat
+	}
+	if body.Duration != nil {
+		duration = time.Duration(*body.Duration) * time.Second
+	}
+	return hub.NewNotice(name, img, priority, duration, repeat), nil
+}
@@ -16,8 +16,11 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	durable "github.com/joe714/pixelgw/internal/durable"
+	schedule "github.com/joe714/pixelgw/internal/schedule"
 	"github.com/oapi-codegen/runtime"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -43,38 +46,117 @@ type App struct {
 
 // AppInstanceDetail defines model for AppInstanceDetail.
 type AppInstanceDetail struct {
-	// AppID Applet ID
+	// AppID Applet ID, empty for images
 	AppID string `json:"app-id"`
 
 	// Config Applet configuration
 	Config json.RawMessage `json:"config,omitempty"`
 
+	// DisplayTime Seconds to display the applet, unset to use the channel default
+	DisplayTime *int `json:"display-time,omitempty"`
+
 	// Idx App position
 	Idx *int `json:"idx,omitempty"`
 
+	// InstallationID ID an image was installed under
+	InstallationID *string `json:"installation-id,omitempty"`
+
+	// Kind applet, or image for a pinned installation
+	Kind *string `json:"kind,omitempty"`
+
+	// RefreshInterval Seconds to reuse a render before running the applet again. Unset to render every rotation, unless the applet sets its own max_age.
+	RefreshInterval *int `json:"refresh-interval,omitempty"`
+
+	// RenderTimeout Seconds the applet may run before it is abandoned, unset to use the server default
+	RenderTimeout *int `json:"render-timeout,omitempty"`
+
+	// Schedule When the applet is in rotation. Every list that is set must match; an empty schedule always shows the applet.
+	Schedule *AppletSchedule `json:"schedule,omitempty"`
+
+	// Status Render failure state, absent if the applet hasn't run since the server started
+	Status *AppletStatus `json:"status,omitempty"`
+
 	// UUID App instance UUID
 	UUID *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
 // AppInstanceSummary defines model for AppInstanceSummary.
 type AppInstanceSummary struct {
-	// AppID Applet ID
+	// AppID Applet ID, empty for images
 	AppID string `json:"app-id"`
 
 	// Config Applet configuration
 	Config json.RawMessage `json:"config,omitempty"`
 
+	// DisplayTime Seconds to display the applet, unset to use the channel default
+	DisplayTime *int `json:"display-time,omitempty"`
+
 	// Idx App position
 	Idx *int `json:"idx,omitempty"`
+
+	// InstallationID ID an image was installed under
+	InstallationID *string `json:"installation-id,omitempty"`
+
+	// Kind applet, or image for a pinned installation
+	Kind *string `json:"kind,omitempty"`
+
+	// RefreshInterval Seconds to reuse a render before running the applet again. Unset to render every rotation, unless the applet sets its own max_age.
+	RefreshInterval *int `json:"refresh-interval,omitempty"`
+
+	// RenderTimeout Seconds the applet may run before it is abandoned, unset to use the server default
+	RenderTimeout *int `json:"render-timeout,omitempty"`
+
+	// Schedule When the applet is in rotation. Every list that is set must match; an empty schedule always shows the applet.
+	Schedule *AppletSchedule `json:"schedule,omitempty"`
+}
+
+// AppletSchedule When the applet is in rotation. Every list that is set must match; an empty schedule always shows the applet.
+type AppletSchedule = schedule.Schedule
+
+// AppletStatus Render failure state, absent if the applet hasn't run since the server started
+type AppletStatus struct {
+	// Failures Consecutive failed renders
+	Failures *int `json:"failures,omitempty"`
+
+	// LastError Error from the most recent failure
+	LastError   *string    `json:"last-error,omitempty"`
+	LastFailure *time.Time `json:"last-failure,omitempty"`
+	LastSuccess *time.Time `json:"last-success,omitempty"`
+
+	// Quarantined The applet is no longer run until its quarantine is cleared
+	Quarantined *bool `json:"quarantined,omitempty"`
+
+	// RetryAt The applet is skipped until this time after a failure
+	RetryAt *time.Time `json:"retry-at,omitempty"`
 }
 
 // ChannelDetail defines model for ChannelDetail.
 type ChannelDetail struct {
-	Applets *[]AppInstanceDetail `json:"applets,omitempty"`
+	// Anonymous Allow anonymous viewers, connecting to /ws with the channel UUID or #name instead of a device
+	Anonymous *bool                `json:"anonymous,omitempty"`
+	Applets   *[]AppInstanceDetail `json:"applets,omitempty"`
 
 	// Comment Comment for the channel
 	Comment *string `json:"comment,omitempty"`
 
+	// DisplayTime Default seconds to display each applet
+	DisplayTime *int `json:"display-time,omitempty"`
+
+	// Fallback What to show when no applet can render: none (keep the last image), diagnostic (channel name and error), or applet (run fallback-app-id). Defaults to diagnostic.
+	Fallback *string `json:"fallback,omitempty"`
+
+	// FallbackAppID Applet ID to run when fallback is applet
+	FallbackAppID *string `json:"fallback-app-id,omitempty"`
+
+	// FallbackConfig Configuration for the fallback applet
+	FallbackConfig json.RawMessage `json:"fallback-config,omitempty"`
+
+	// FitAnimation Extend the default display time to complete animation loops
+	FitAnimation *bool `json:"fit-animation,omitempty"`
+
+	// Location Where the channel's devices are, for applet schedules. An empty location uses the server's time zone and disables sunrise and sunset.
+	Location *ChannelLocation `json:"location,omitempty"`
+
 	// Name Name of the channel
 	Name        string       `json:"name"`
 	Subscribers *[]DeviceRef `json:"subscribers,omitempty"`
@@ -83,6 +165,15 @@ type ChannelDetail struct {
 	UUID *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
+// ChannelLocation Where the channel's devices are, for applet schedules. An empty location uses the server's time zone and disables sunrise and sunset.
+type ChannelLocation struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+
+	// Timezone IANA time zone, such as America/New_York
+	Timezone *string `json:"timezone,omitempty"`
+}
+
 // ChannelRef defines model for ChannelRef.
 type ChannelRef struct {
 	// Name Name of the channel
@@ -94,9 +185,30 @@ type ChannelRef struct {
 
 // ChannelSummary defines model for ChannelSummary.
 type ChannelSummary struct {
+	// Anonymous Allow anonymous viewers, connecting to /ws with the channel UUID or #name instead of a device
+	Anonymous *bool `json:"anonymous,omitempty"`
+
 	// Comment Comment for the channel
 	Comment *string `json:"comment,omitempty"`
 
+	// DisplayTime Default seconds to display each applet
+	DisplayTime *int `json:"display-time,omitempty"`
+
+	// Fallback What to show when no applet can render: none (keep the last image), diagnostic (channel name and error), or applet (run fallback-app-id). Defaults to diagnostic.
+	Fallback *string `json:"fallback,omitempty"`
+
+	// FallbackAppID Applet ID to run when fallback is applet
+	FallbackAppID *string `json:"fallback-app-id,omitempty"`
+
+	// FallbackConfig Configuration for the fallback applet
+	FallbackConfig json.RawMessage `json:"fallback-config,omitempty"`
+
+	// FitAnimation Extend the default display time to complete animation loops
+	FitAnimation *bool `json:"fit-animation,omitempty"`
+
+	// Location Where the channel's devices are, for applet schedules. An empty location uses the server's time zone and disables sunrise and sunset.
+	Location *ChannelLocation `json:"location,omitempty"`
+
 	// Name Name of the channel
 	Name string `json:"name"`
 
@@ -104,6 +216,48 @@ type ChannelSummary struct {
 	UUID *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
+// DeviceCommand defines model for DeviceCommand.
+type DeviceCommand struct {
+	// Brightness Brightness percentage for the brightness command
+	Brightness *int `json:"brightness,omitempty"`
+
+	// Command brightness or reboot
+	Command string `json:"command"`
+}
+
+// DeviceConnection defines model for DeviceConnection.
+type DeviceConnection struct {
+	Connected *time.Time `json:"connected,omitempty"`
+
+	// Disconnected Absent while connected, or if the server stopped first
+	Disconnected *time.Time `json:"disconnected,omitempty"`
+
+	// Frames Images sent during the session
+	Frames     *uint64             `json:"frames,omitempty"`
+	ID         *openapi_types.UUID `json:"id,omitempty"`
+	LastFrame  *time.Time          `json:"last-frame,omitempty"`
+	RemoteAddr *string             `json:"remote-addr,omitempty"`
+	SessionID  *uint32             `json:"session-id,omitempty"`
+}
+
+// DeviceProtocol What the device reported over the control protocol, absent if it hasn't said hello
+type DeviceProtocol struct {
+	Capabilities *[]string `json:"capabilities,omitempty"`
+
+	// Firmware Firmware version
+	Firmware *string `json:"firmware,omitempty"`
+	Height   *int    `json:"height,omitempty"`
+
+	// LastAck Sequence number of the last frame the device displayed
+	LastAck     *uint64    `json:"last-ack,omitempty"`
+	LastAckTime *time.Time `json:"last-ack-time,omitempty"`
+	MaxFrames   *int       `json:"max-frames,omitempty"`
+
+	// Version Negotiated protocol version
+	Version *int `json:"version,omitempty"`
+	Width   *int `json:"width,omitempty"`
+}
+
 // DeviceRef defines model for DeviceRef.
 type DeviceRef struct {
 	// Name Name of the channel
@@ -115,15 +269,71 @@ type DeviceRef struct {
 
 // DeviceSummary defines model for DeviceSummary.
 type DeviceSummary struct {
-	Channel *ChannelRef `json:"channel,omitempty"`
+	// Brightness Brightness percentage, absent for full brightness
+	Brightness *int        `json:"brightness,omitempty"`
+	Channel    *ChannelRef `json:"channel,omitempty"`
+
+	// ConnectedSince Start of the oldest live session
+	ConnectedSince *time.Time     `json:"connected-since,omitempty"`
+	Dimming        *[]DimmingRule `json:"dimming,omitempty"`
+
+	// Format Image format setting, absent for the default
+	Format *string `json:"format,omitempty"`
+
+	// LastAddr Address the device last connected from
+	LastAddr *string `json:"last-addr,omitempty"`
+
+	// LastSeen Last connect, disconnect or frame sent, saved every minute or so
+	LastSeen *time.Time `json:"last-seen,omitempty"`
 
 	// Name Name of the channel
 	Name *string `json:"name,omitempty"`
 
+	// Online Whether the device has a live session
+	Online *bool `json:"online,omitempty"`
+
+	// PairingCode Code shown on a device pending approval
+	PairingCode *string `json:"pairing-code,omitempty"`
+
+	// State approved, pending or rejected
+	State *string `json:"state,omitempty"`
+
+	// TokenIssued When the device's current token was issued
+	TokenIssued *time.Time `json:"token-issued,omitempty"`
+
+	// TokenRequired Whether the device must present a token to connect
+	TokenRequired *bool `json:"token-required,omitempty"`
+
+	// Uptime Seconds since connected-since
+	Uptime *int `json:"uptime,omitempty"`
+
 	// UUID UUID of the device
 	UUID *openapi_types.UUID `json:"uuid,omitempty"`
 }
 
+// DeviceToken defines model for DeviceToken.
+type DeviceToken struct {
+	// Token Secret the device presents when it connects
+	Token string `json:"token"`
+}
+
+// DimmingRule Brightness while the schedule is active. The first active rule wins. Sun times are at the location of the device's channel.
+type DimmingRule = durable.DimmingRule
+
+// DisplayProfile What images sent to the session are rendered for
+type DisplayProfile struct {
+	// Brightness Percent the server scales pixels by, 100 when the device dims itself
+	Brightness *int `json:"brightness,omitempty"`
+
+	// Format Image encoding
+	Format *string `json:"format,omitempty"`
+	Height *int    `json:"height,omitempty"`
+
+	// MaxFrames Longest animation sent, 0 for no limit
+	MaxFrames *int `json:"max-frames,omitempty"`
+	Width     *int `json:"width,omitempty"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	// Code Error code
@@ -133,9 +343,48 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// ImageInstallation defines model for ImageInstallation.
+type ImageInstallation struct {
+	// Idx Position in the rotation, counting from 0. Omit it or use -1 to add a new image at the end, or keep an existing image in place.
+	Idx *int `json:"idx,omitempty"`
+
+	// Image Base64 encoded WebP, GIF or PNG image
+	Image []byte `json:"image"`
+}
+
 // Notification defines model for Notification.
 type Notification = SchemaField
 
+// NotificationRequest An image shown ahead of the normal rotation, either uploaded or rendered from an applet. Set one of image or app-id. A higher priority notification interrupts a lower one.
+type NotificationRequest struct {
+	// AppID Applet to render
+	AppID *string `json:"app-id,omitempty"`
+
+	// Config Applet configuration
+	Config json.RawMessage `json:"config,omitempty"`
+
+	// Duration Seconds to show it each time, defaults to 15
+	Duration *int `json:"duration,omitempty"`
+
+	// Image Base64 encoded WebP, GIF or PNG image
+	Image *[]byte `json:"image,omitempty"`
+
+	// Name Name for logging
+	Name *string `json:"name,omitempty"`
+
+	// Priority Higher priorities interrupt lower ones, defaults to 0
+	Priority *int `json:"priority,omitempty"`
+
+	// Repeat Times to show it, defaults to 1
+	Repeat *int `json:"repeat,omitempty"`
+}
+
+// ScheduleDates Inclusive date range, YYYY-MM-DD
+type ScheduleDates = schedule.DateRange
+
+// ScheduleWindow Time of day range in the channel's time zone. Times are HH:MM, sunrise or sunset, with an optional offset such as sunset-30m. Sunrise and sunset need the channel's latitude and longitude. A window that ends before it starts runs past midnight.
+type ScheduleWindow = schedule.Window
+
 // Schema defines model for Schema.
 type Schema = schema.Schema
 
@@ -150,12 +399,27 @@ type SchemaVisibility = schema.SchemaVisibility
 
 // SessionSummary defines model for SessionSummary.
 type SessionSummary struct {
-	Channel *ChannelRef `json:"channel,omitempty"`
-	Device  *DeviceRef  `json:"device,omitempty"`
+	// Anonymous An anonymous viewer of the channel, with no device
+	Anonymous *bool       `json:"anonymous,omitempty"`
+	Channel   *ChannelRef `json:"channel,omitempty"`
+	Connected *time.Time  `json:"connected,omitempty"`
+	Device    *DeviceRef  `json:"device,omitempty"`
+
+	// Display What images sent to the session are rendered for
+	Display *DisplayProfile `json:"display,omitempty"`
+
+	// Dropped Frames replaced by a newer frame before they could be sent
+	Dropped *uint64 `json:"dropped,omitempty"`
 
 	// ID Session ID
 	ID *uint32 `json:"id,omitempty"`
 
+	// Pending The device is awaiting approval, so isn't on a channel
+	Pending *bool `json:"pending,omitempty"`
+
+	// Protocol What the device reported over the control protocol, absent if it hasn't said hello
+	Protocol *DeviceProtocol `json:"protocol,omitempty"`
+
 	// RemoteAddr Remote IP address
 	RemoteAddr *string `json:"remote-addr,omitempty"`
 }
@@ -174,18 +438,86 @@ type PatchChannelAppletJSONBody struct {
 	// Config Applet configuration
 	Config json.RawMessage `json:"config,omitempty"`
 
+	// DisplayTime Seconds to display the applet, 0 to use the channel default
+	DisplayTime *int `json:"display-time,omitempty"`
+
 	// Idx App position
 	Idx *int `json:"idx,omitempty"`
+
+	// RefreshInterval Seconds to reuse a render before running the applet again, 0 to render every time
+	RefreshInterval *int `json:"refresh-interval,omitempty"`
+
+	// RenderTimeout Seconds the applet may run before it is abandoned, 0 to use the server default
+	RenderTimeout *int `json:"render-timeout,omitempty"`
+
+	// Schedule When the applet is in rotation. Every list that is set must match; an empty schedule always shows the applet.
+	Schedule *AppletSchedule `json:"schedule,omitempty"`
+}
+
+// PatchChannelJSONBody defines parameters for PatchChannel.
+type PatchChannelJSONBody struct {
+	// Anonymous Allow anonymous viewers, connecting to /ws with a channel instead of a device. Turning it off disconnects them.
+	Anonymous *bool `json:"anonymous,omitempty"`
+
+	// Comment Comment for the channel
+	Comment *string `json:"comment,omitempty"`
+
+	// DisplayTime Default seconds to display each applet, 0 to clear
+	DisplayTime *int `json:"display-time,omitempty"`
+
+	// Fallback What to show when no applet can render (none, diagnostic or applet)
+	Fallback *string `json:"fallback,omitempty"`
+
+	// FallbackAppID Applet ID to run when fallback is applet
+	FallbackAppID *string `json:"fallback-app-id,omitempty"`
+
+	// FallbackConfig Configuration for the fallback applet
+	FallbackConfig json.RawMessage `json:"fallback-config,omitempty"`
+
+	// FitAnimation Extend the default display time to complete animation loops
+	FitAnimation *bool `json:"fit-animation,omitempty"`
+
+	// Location Where the channel's devices are, for applet schedules. An empty location uses the server's time zone and disables sunrise and sunset.
+	Location *ChannelLocation `json:"location,omitempty"`
+
+	// Name Name of the channel
+	Name *string `json:"name,omitempty"`
+}
+
+// GetDevicesParams defines parameters for GetDevices.
+type GetDevicesParams struct {
+	// State Only list devices in this state, approved, pending or rejected
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+}
+
+// DeleteDeviceParams defines parameters for DeleteDevice.
+type DeleteDeviceParams struct {
+	// Block Seconds to refuse the device if it tries to enroll again
+	Block *int `form:"block,omitempty" json:"block,omitempty"`
 }
 
 // PatchDeviceJSONBody defines parameters for PatchDevice.
 type PatchDeviceJSONBody struct {
-	Channel *ChannelRef `json:"channel,omitempty"`
+	// Brightness Brightness percentage when no dimming rule applies
+	Brightness *int        `json:"brightness,omitempty"`
+	Channel    *ChannelRef `json:"channel,omitempty"`
+
+	// Dimming Replaces the dimming rules, empty to remove them
+	Dimming *[]DimmingRule `json:"dimming,omitempty"`
+
+	// Format Image format sent to the device, one of webp, gif, png, rgb888 or rgb565. Empty to use webp, or whatever the device asks for when it connects.
+	Format *string `json:"format,omitempty"`
 
 	// Name Device name
 	Name *string `json:"name,omitempty"`
 }
 
+// GetSessionsParams defines parameters for GetSessions.
+type GetSessionsParams struct {
+	// Anonymous Only anonymous viewers if true, only devices if false
+	Anonymous *bool `form:"anonymous,omitempty" json:"anonymous,omitempty"`
+}
+
 // CreateChannelJSONRequestBody defines body for CreateChannel for application/json ContentType.
 type CreateChannelJSONRequestBody = ChannelSummary
 
@@ -195,6 +527,21 @@ type CreateChannelAppletJSONRequestBody = AppInstanceSummary
 // PatchChannelAppletJSONRequestBody defines body for PatchChannelApplet for application/json ContentType.
 type PatchChannelAppletJSONRequestBody PatchChannelAppletJSONBody
 
+// PutChannelInstallationJSONRequestBody defines body for PutChannelInstallation for application/json ContentType.
+type PutChannelInstallationJSONRequestBody = ImageInstallation
+
+// NotifyChannelJSONRequestBody defines body for NotifyChannel for application/json ContentType.
+type NotifyChannelJSONRequestBody = NotificationRequest
+
+// PatchChannelJSONRequestBody defines body for PatchChannel for application/json ContentType.
+type PatchChannelJSONRequestBody PatchChannelJSONBody
+
+// SendDeviceCommandJSONRequestBody defines body for SendDeviceCommand for application/json ContentType.
+type SendDeviceCommandJSONRequestBody = DeviceCommand
+
+// NotifyDeviceJSONRequestBody defines body for NotifyDevice for application/json ContentType.
+type NotifyDeviceJSONRequestBody = NotificationRequest
+
 // PatchDeviceJSONRequestBody defines body for PatchDevice for application/json ContentType.
 type PatchDeviceJSONRequestBody PatchDeviceJSONBody
 
@@ -222,11 +569,50 @@ type ServerInterface interface {
 	// (PATCH /channels/{channelUUID}/applets/{appletUUID})
 	PatchChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID)
 
+	// (DELETE /channels/{channelUUID}/applets/{appletUUID}/quarantine)
+	ClearAppletQuarantine(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID)
+
+	// (PUT /channels/{channelUUID}/installations/{installationID})
+	PutChannelInstallation(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, installationID string)
+
+	// (POST /channels/{channelUUID}/notifications)
+	NotifyChannel(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID)
+
+	// (DELETE /channels/{uuid})
+	DeleteChannel(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+
 	// (GET /channels/{uuid})
 	FindChannelByUUID(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
+
+	// (PATCH /channels/{uuid})
+	PatchChannel(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
 	// Get configured devices
 	// (GET /devices)
-	GetDevices(w http.ResponseWriter, r *http.Request)
+	GetDevices(w http.ResponseWriter, r *http.Request, params GetDevicesParams)
+
+	// (POST /devices/{deviceUUID}/approve)
+	ApproveDevice(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (POST /devices/{deviceUUID}/commands)
+	SendDeviceCommand(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (GET /devices/{deviceUUID}/connections)
+	GetDeviceConnections(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (POST /devices/{deviceUUID}/notifications)
+	NotifyDevice(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (POST /devices/{deviceUUID}/reject)
+	RejectDevice(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (DELETE /devices/{deviceUUID}/token)
+	RevokeDeviceToken(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (POST /devices/{deviceUUID}/token)
+	RotateDeviceToken(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID)
+
+	// (DELETE /devices/{uuid})
+	DeleteDevice(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID, params DeleteDeviceParams)
 
 	// (GET /devices/{uuid})
 	GetDeviceByUUID(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
@@ -235,7 +621,7 @@ type ServerInterface interface {
 	PatchDevice(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID)
 	// Get connected sessions
 	// (GET /sessions)
-	GetSessions(w http.ResponseWriter, r *http.Request)
+	GetSessions(w http.ResponseWriter, r *http.Request, params GetSessionsParams)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -427,23 +813,32 @@ func (siw *ServerInterfaceWrapper) PatchChannelApplet(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// FindChannelByUUID operation middleware
-func (siw *ServerInterfaceWrapper) FindChannelByUUID(w http.ResponseWriter, r *http.Request) {
+// ClearAppletQuarantine operation middleware
+func (siw *ServerInterfaceWrapper) ClearAppletQuarantine(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "uuid" -------------
-	var uuid openapi_types.UUID
+	// ------------- Path parameter "channelUUID" -------------
+	var channelUUID openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "channelUUID", r.PathValue("channelUUID"), &channelUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "channelUUID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "appletUUID" -------------
+	var appletUUID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "appletUUID", r.PathValue("appletUUID"), &appletUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "appletUUID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.FindChannelByUUID(w, r, uuid)
+		siw.Handler.ClearAppletQuarantine(w, r, channelUUID, appletUUID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -453,12 +848,58 @@ func (siw *ServerInterfaceWrapper) FindChannelByUUID(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetDevices operation middleware
-func (siw *ServerInterfaceWrapper) GetDevices(w http.ResponseWriter, r *http.Request) {
+// PutChannelInstallation operation middleware
+func (siw *ServerInterfaceWrapper) PutChannelInstallation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "channelUUID" -------------
+	var channelUUID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "channelUUID", r.PathValue("channelUUID"), &channelUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "channelUUID", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "installationID" -------------
+	var installationID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "installationID", r.PathValue("installationID"), &installationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "installationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutChannelInstallation(w, r, channelUUID, installationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// NotifyChannel operation middleware
+func (siw *ServerInterfaceWrapper) NotifyChannel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "channelUUID" -------------
+	var channelUUID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "channelUUID", r.PathValue("channelUUID"), &channelUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "channelUUID", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDevices(w, r)
+		siw.Handler.NotifyChannel(w, r, channelUUID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -468,8 +909,8 @@ func (siw *ServerInterfaceWrapper) GetDevices(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetDeviceByUUID operation middleware
-func (siw *ServerInterfaceWrapper) GetDeviceByUUID(w http.ResponseWriter, r *http.Request) {
+// DeleteChannel operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChannel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -484,7 +925,7 @@ func (siw *ServerInterfaceWrapper) GetDeviceByUUID(w http.ResponseWriter, r *htt
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDeviceByUUID(w, r, uuid)
+		siw.Handler.DeleteChannel(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -494,8 +935,8 @@ func (siw *ServerInterfaceWrapper) GetDeviceByUUID(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PatchDevice operation middleware
-func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Request) {
+// FindChannelByUUID operation middleware
+func (siw *ServerInterfaceWrapper) FindChannelByUUID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -510,7 +951,7 @@ func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Re
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchDevice(w, r, uuid)
+		siw.Handler.FindChannelByUUID(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -520,12 +961,23 @@ func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetSessions operation middleware
-func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
+// PatchChannel operation middleware
+func (siw *ServerInterfaceWrapper) PatchChannel(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetSessions(w, r)
+		siw.Handler.PatchChannel(w, r, uuid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -535,600 +987,1719 @@ func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// GetDevices operation middleware
+func (siw *ServerInterfaceWrapper) GetDevices(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDevicesParams
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "state" -------------
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDevices(w, r, params)
+	}))
 
-type RequiredParamError struct {
-	ParamName string
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+// ApproveDevice operation middleware
+func (siw *ServerInterfaceWrapper) ApproveDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	var err error
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveDevice(w, r, deviceUUID)
+	}))
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+// SendDeviceCommand operation middleware
+func (siw *ServerInterfaceWrapper) SendDeviceCommand(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+	var err error
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       *http.ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m *http.ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
+	}
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m *http.ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SendDeviceCommand(w, r, deviceUUID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+// GetDeviceConnections operation middleware
+func (siw *ServerInterfaceWrapper) GetDeviceConnections(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+	var err error
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
 	}
 
-	m.HandleFunc("GET "+options.BaseURL+"/applets", wrapper.GetApplets)
-	m.HandleFunc("GET "+options.BaseURL+"/applets/{id}", wrapper.GetAppletByID)
-	m.HandleFunc("GET "+options.BaseURL+"/channels", wrapper.GetChannels)
-	m.HandleFunc("POST "+options.BaseURL+"/channels", wrapper.CreateChannel)
-	m.HandleFunc("POST "+options.BaseURL+"/channels/{channelUUID}/applets", wrapper.CreateChannelApplet)
-	m.HandleFunc("DELETE "+options.BaseURL+"/channels/{channelUUID}/applets/{appletUUID}", wrapper.DeleteChannelApplet)
-	m.HandleFunc("PATCH "+options.BaseURL+"/channels/{channelUUID}/applets/{appletUUID}", wrapper.PatchChannelApplet)
-	m.HandleFunc("GET "+options.BaseURL+"/channels/{uuid}", wrapper.FindChannelByUUID)
-	m.HandleFunc("GET "+options.BaseURL+"/devices", wrapper.GetDevices)
-	m.HandleFunc("GET "+options.BaseURL+"/devices/{uuid}", wrapper.GetDeviceByUUID)
-	m.HandleFunc("PATCH "+options.BaseURL+"/devices/{uuid}", wrapper.PatchDevice)
-	m.HandleFunc("GET "+options.BaseURL+"/sessions", wrapper.GetSessions)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDeviceConnections(w, r, deviceUUID)
+	}))
 
-	return m
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type DefaultErrorResponseJSONResponse Error
+// NotifyDevice operation middleware
+func (siw *ServerInterfaceWrapper) NotifyDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type GetAppletsRequestObject struct {
-	Params GetAppletsParams
-}
+	var err error
 
-type GetAppletsResponseObject interface {
-	VisitGetAppletsResponse(w http.ResponseWriter) error
-}
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
 
-type GetApplets200JSONResponse []App
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
+	}
 
-func (response GetApplets200JSONResponse) VisitGetAppletsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.NotifyDevice(w, r, deviceUUID)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetAppletsdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (response GetAppletsdefaultJSONResponse) VisitGetAppletsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// RejectDevice operation middleware
+func (siw *ServerInterfaceWrapper) RejectDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	var err error
 
-type GetAppletByIDRequestObject struct {
-	Id string `json:"id"`
-}
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
 
-type GetAppletByIDResponseObject interface {
-	VisitGetAppletByIDResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
+	}
 
-type GetAppletByID200JSONResponse App
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectDevice(w, r, deviceUUID)
+	}))
 
-func (response GetAppletByID200JSONResponse) VisitGetAppletByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetAppletByID404JSONResponse Error
+// RevokeDeviceToken operation middleware
+func (siw *ServerInterfaceWrapper) RevokeDeviceToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response GetAppletByID404JSONResponse) VisitGetAppletByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
 
-type GetAppletByIDdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
+	}
 
-func (response GetAppletByIDdefaultJSONResponse) VisitGetAppletByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeDeviceToken(w, r, deviceUUID)
+	}))
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetChannelsRequestObject struct {
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type GetChannelsResponseObject interface {
-	VisitGetChannelsResponse(w http.ResponseWriter) error
-}
+// RotateDeviceToken operation middleware
+func (siw *ServerInterfaceWrapper) RotateDeviceToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type GetChannels200JSONResponse []ChannelSummary
+	var err error
 
-func (response GetChannels200JSONResponse) VisitGetChannelsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "deviceUUID" -------------
+	var deviceUUID openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "deviceUUID", r.PathValue("deviceUUID"), &deviceUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "deviceUUID", Err: err})
+		return
+	}
 
-type GetChannelsdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RotateDeviceToken(w, r, deviceUUID)
+	}))
 
-func (response GetChannelsdefaultJSONResponse) VisitGetChannelsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response.Body)
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateChannelRequestObject struct {
-	Body *CreateChannelJSONRequestBody
-}
+// DeleteDevice operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type CreateChannelResponseObject interface {
-	VisitCreateChannelResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type CreateChannel201JSONResponse ChannelDetail
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
 
-func (response CreateChannel201JSONResponse) VisitCreateChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteDeviceParams
 
-type CreateChanneldefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+	// ------------- Optional query parameter "block" -------------
 
-func (response CreateChanneldefaultJSONResponse) VisitCreateChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+	err = runtime.BindQueryParameter("form", true, false, "block", r.URL.Query(), &params.Block)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "block", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response.Body)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDevice(w, r, uuid, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateChannelAppletRequestObject struct {
-	ChannelUUID openapi_types.UUID `json:"channelUUID"`
-	Body        *CreateChannelAppletJSONRequestBody
-}
+// GetDeviceByUUID operation middleware
+func (siw *ServerInterfaceWrapper) GetDeviceByUUID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type CreateChannelAppletResponseObject interface {
-	VisitCreateChannelAppletResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type CreateChannelApplet201JSONResponse AppInstanceDetail
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
 
-func (response CreateChannelApplet201JSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDeviceByUUID(w, r, uuid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type CreateChannelApplet400JSONResponse Error
+// PatchDevice operation middleware
+func (siw *ServerInterfaceWrapper) PatchDevice(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (response CreateChannelApplet400JSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "uuid" -------------
+	var uuid openapi_types.UUID
 
-type CreateChannelApplet404JSONResponse Error
+	err = runtime.BindStyledParameterWithOptions("simple", "uuid", r.PathValue("uuid"), &uuid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uuid", Err: err})
+		return
+	}
 
-func (response CreateChannelApplet404JSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchDevice(w, r, uuid)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CreateChannelAppletdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-func (response CreateChannelAppletdefaultJSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response.Body)
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSessionsParams
+
+	// ------------- Optional query parameter "anonymous" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "anonymous", r.URL.Query(), &params.Anonymous)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "anonymous", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type DeleteChannelAppletRequestObject struct {
-	ChannelUUID openapi_types.UUID `json:"channelUUID"`
-	AppletUUID  openapi_types.UUID `json:"appletUUID"`
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
 }
 
-type DeleteChannelAppletResponseObject interface {
-	VisitDeleteChannelAppletResponse(w http.ResponseWriter) error
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-type DeleteChannelApplet200Response struct {
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
 }
 
-func (response DeleteChannelApplet200Response) VisitDeleteChannelAppletResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
 }
 
-type DeleteChannelAppletdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
 }
 
-func (response DeleteChannelAppletdefaultJSONResponse) VisitDeleteChannelAppletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response.Body)
+type RequiredParamError struct {
+	ParamName string
 }
 
-type PatchChannelAppletRequestObject struct {
-	ChannelUUID openapi_types.UUID `json:"channelUUID"`
-	AppletUUID  openapi_types.UUID `json:"appletUUID"`
-	Body        *PatchChannelAppletJSONRequestBody
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
 }
 
-type PatchChannelAppletResponseObject interface {
-	VisitPatchChannelAppletResponse(w http.ResponseWriter) error
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
 }
 
-type PatchChannelApplet200Response struct {
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
 }
 
-func (response PatchChannelApplet200Response) VisitPatchChannelAppletResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
 }
 
-type PatchChannelAppletdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
 }
 
-func (response PatchChannelAppletdefaultJSONResponse) VisitPatchChannelAppletResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
 
-	return json.NewEncoder(w).Encode(response.Body)
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
 }
 
-type FindChannelByUUIDRequestObject struct {
-	UUID openapi_types.UUID `json:"uuid"`
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
 }
 
-type FindChannelByUUIDResponseObject interface {
-	VisitFindChannelByUUIDResponse(w http.ResponseWriter) error
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
 }
 
-type FindChannelByUUID200JSONResponse ChannelDetail
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
 
-func (response FindChannelByUUID200JSONResponse) VisitFindChannelByUUIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       *http.ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
 
-	return json.NewEncoder(w).Encode(response)
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m *http.ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
 }
 
-type FindChannelByUUIDdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+func HandlerFromMuxWithBaseURL(si ServerInterface, m *http.ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
 }
 
-func (response FindChannelByUUIDdefaultJSONResponse) VisitFindChannelByUUIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
 
-	return json.NewEncoder(w).Encode(response.Body)
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/applets", wrapper.GetApplets)
+	m.HandleFunc("GET "+options.BaseURL+"/applets/{id}", wrapper.GetAppletByID)
+	m.HandleFunc("GET "+options.BaseURL+"/channels", wrapper.GetChannels)
+	m.HandleFunc("POST "+options.BaseURL+"/channels", wrapper.CreateChannel)
+	m.HandleFunc("POST "+options.BaseURL+"/channels/{channelUUID}/applets", wrapper.CreateChannelApplet)
+	m.HandleFunc("DELETE "+options.BaseURL+"/channels/{channelUUID}/applets/{appletUUID}", wrapper.DeleteChannelApplet)
+	m.HandleFunc("PATCH "+options.BaseURL+"/channels/{channelUUID}/applets/{appletUUID}", wrapper.PatchChannelApplet)
+	m.HandleFunc("DELETE "+options.BaseURL+"/channels/{channelUUID}/applets/{appletUUID}/quarantine", wrapper.ClearAppletQuarantine)
+	m.HandleFunc("PUT "+options.BaseURL+"/channels/{channelUUID}/installations/{installationID}", wrapper.PutChannelInstallation)
+	m.HandleFunc("POST "+options.BaseURL+"/channels/{channelUUID}/notifications", wrapper.NotifyChannel)
+	m.HandleFunc("DELETE "+options.BaseURL+"/channels/{uuid}", wrapper.DeleteChannel)
+	m.HandleFunc("GET "+options.BaseURL+"/channels/{uuid}", wrapper.FindChannelByUUID)
+	m.HandleFunc("PATCH "+options.BaseURL+"/channels/{uuid}", wrapper.PatchChannel)
+	m.HandleFunc("GET "+options.BaseURL+"/devices", wrapper.GetDevices)
+	m.HandleFunc("POST "+options.BaseURL+"/devices/{deviceUUID}/approve", wrapper.ApproveDevice)
+	m.HandleFunc("POST "+options.BaseURL+"/devices/{deviceUUID}/commands", wrapper.SendDeviceCommand)
+	m.HandleFunc("GET "+options.BaseURL+"/devices/{deviceUUID}/connections", wrapper.GetDeviceConnections)
+	m.HandleFunc("POST "+options.BaseURL+"/devices/{deviceUUID}/notifications", wrapper.NotifyDevice)
+	m.HandleFunc("POST "+options.BaseURL+"/devices/{deviceUUID}/reject", wrapper.RejectDevice)
+	m.HandleFunc("DELETE "+options.BaseURL+"/devices/{deviceUUID}/token", wrapper.RevokeDeviceToken)
+	m.HandleFunc("POST "+options.BaseURL+"/devices/{deviceUUID}/token", wrapper.RotateDeviceToken)
+	m.HandleFunc("DELETE "+options.BaseURL+"/devices/{uuid}", wrapper.DeleteDevice)
+	m.HandleFunc("GET "+options.BaseURL+"/devices/{uuid}", wrapper.GetDeviceByUUID)
+	m.HandleFunc("PATCH "+options.BaseURL+"/devices/{uuid}", wrapper.PatchDevice)
+	m.HandleFunc("GET "+options.BaseURL+"/sessions", wrapper.GetSessions)
+
+	return m
 }
 
-type GetDevicesRequestObject struct {
+type DefaultErrorResponseJSONResponse Error
+
+type GetAppletsRequestObject struct {
+	Params GetAppletsParams
 }
 
-type GetDevicesResponseObject interface {
-	VisitGetDevicesResponse(w http.ResponseWriter) error
+type GetAppletsResponseObject interface {
+	VisitGetAppletsResponse(w http.ResponseWriter) error
 }
 
-type GetDevices200JSONResponse []DeviceSummary
+type GetApplets200JSONResponse []App
 
-func (response GetDevices200JSONResponse) VisitGetDevicesResponse(w http.ResponseWriter) error {
+func (response GetApplets200JSONResponse) VisitGetAppletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDevicesdefaultJSONResponse struct {
+type GetAppletsdefaultJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response GetDevicesdefaultJSONResponse) VisitGetDevicesResponse(w http.ResponseWriter) error {
+func (response GetAppletsdefaultJSONResponse) VisitGetAppletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetDeviceByUUIDRequestObject struct {
-	UUID openapi_types.UUID `json:"uuid"`
+type GetAppletByIDRequestObject struct {
+	Id string `json:"id"`
 }
 
-type GetDeviceByUUIDResponseObject interface {
-	VisitGetDeviceByUUIDResponse(w http.ResponseWriter) error
+type GetAppletByIDResponseObject interface {
+	VisitGetAppletByIDResponse(w http.ResponseWriter) error
 }
 
-type GetDeviceByUUID200JSONResponse DeviceSummary
+type GetAppletByID200JSONResponse App
 
-func (response GetDeviceByUUID200JSONResponse) VisitGetDeviceByUUIDResponse(w http.ResponseWriter) error {
+func (response GetAppletByID200JSONResponse) VisitGetAppletByIDResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetDeviceByUUIDdefaultJSONResponse struct {
+type GetAppletByID404JSONResponse Error
+
+func (response GetAppletByID404JSONResponse) VisitGetAppletByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAppletByIDdefaultJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response GetDeviceByUUIDdefaultJSONResponse) VisitGetDeviceByUUIDResponse(w http.ResponseWriter) error {
+func (response GetAppletByIDdefaultJSONResponse) VisitGetAppletByIDResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type PatchDeviceRequestObject struct {
-	UUID openapi_types.UUID `json:"uuid"`
-	Body *PatchDeviceJSONRequestBody
+type GetChannelsRequestObject struct {
 }
 
-type PatchDeviceResponseObject interface {
-	VisitPatchDeviceResponse(w http.ResponseWriter) error
+type GetChannelsResponseObject interface {
+	VisitGetChannelsResponse(w http.ResponseWriter) error
 }
 
-type PatchDevice200Response struct {
+type GetChannels200JSONResponse []ChannelSummary
+
+func (response GetChannels200JSONResponse) VisitGetChannelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response PatchDevice200Response) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+type GetChannelsdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetChannelsdefaultJSONResponse) VisitGetChannelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateChannelRequestObject struct {
+	Body *CreateChannelJSONRequestBody
+}
+
+type CreateChannelResponseObject interface {
+	VisitCreateChannelResponse(w http.ResponseWriter) error
+}
+
+type CreateChannel201JSONResponse ChannelDetail
+
+func (response CreateChannel201JSONResponse) VisitCreateChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateChanneldefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateChanneldefaultJSONResponse) VisitCreateChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateChannelAppletRequestObject struct {
+	ChannelUUID openapi_types.UUID `json:"channelUUID"`
+	Body        *CreateChannelAppletJSONRequestBody
+}
+
+type CreateChannelAppletResponseObject interface {
+	VisitCreateChannelAppletResponse(w http.ResponseWriter) error
+}
+
+type CreateChannelApplet201JSONResponse AppInstanceDetail
+
+func (response CreateChannelApplet201JSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateChannelApplet400JSONResponse Error
+
+func (response CreateChannelApplet400JSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateChannelApplet404JSONResponse Error
+
+func (response CreateChannelApplet404JSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateChannelAppletdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateChannelAppletdefaultJSONResponse) VisitCreateChannelAppletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteChannelAppletRequestObject struct {
+	ChannelUUID openapi_types.UUID `json:"channelUUID"`
+	AppletUUID  openapi_types.UUID `json:"appletUUID"`
+}
+
+type DeleteChannelAppletResponseObject interface {
+	VisitDeleteChannelAppletResponse(w http.ResponseWriter) error
+}
+
+type DeleteChannelApplet200Response struct {
+}
+
+func (response DeleteChannelApplet200Response) VisitDeleteChannelAppletResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
 	return nil
 }
 
-type PatchDevicedefaultJSONResponse struct {
+type DeleteChannelAppletdefaultJSONResponse struct {
 	Body       Error
 	StatusCode int
 }
 
-func (response PatchDevicedefaultJSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+func (response DeleteChannelAppletdefaultJSONResponse) VisitDeleteChannelAppletResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(response.StatusCode)
 
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetSessionsRequestObject struct {
+type PatchChannelAppletRequestObject struct {
+	ChannelUUID openapi_types.UUID `json:"channelUUID"`
+	AppletUUID  openapi_types.UUID `json:"appletUUID"`
+	Body        *PatchChannelAppletJSONRequestBody
 }
 
-type GetSessionsResponseObject interface {
-	VisitGetSessionsResponse(w http.ResponseWriter) error
+type PatchChannelAppletResponseObject interface {
+	VisitPatchChannelAppletResponse(w http.ResponseWriter) error
+}
+
+type PatchChannelApplet200Response struct {
+}
+
+func (response PatchChannelApplet200Response) VisitPatchChannelAppletResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PatchChannelAppletdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PatchChannelAppletdefaultJSONResponse) VisitPatchChannelAppletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ClearAppletQuarantineRequestObject struct {
+	ChannelUUID openapi_types.UUID `json:"channelUUID"`
+	AppletUUID  openapi_types.UUID `json:"appletUUID"`
+}
+
+type ClearAppletQuarantineResponseObject interface {
+	VisitClearAppletQuarantineResponse(w http.ResponseWriter) error
+}
+
+type ClearAppletQuarantine200Response struct {
+}
+
+func (response ClearAppletQuarantine200Response) VisitClearAppletQuarantineResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ClearAppletQuarantinedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ClearAppletQuarantinedefaultJSONResponse) VisitClearAppletQuarantineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutChannelInstallationRequestObject struct {
+	ChannelUUID    openapi_types.UUID `json:"channelUUID"`
+	InstallationID string             `json:"installationID"`
+	Body           *PutChannelInstallationJSONRequestBody
+}
+
+type PutChannelInstallationResponseObject interface {
+	VisitPutChannelInstallationResponse(w http.ResponseWriter) error
+}
+
+type PutChannelInstallation200JSONResponse AppInstanceDetail
+
+func (response PutChannelInstallation200JSONResponse) VisitPutChannelInstallationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutChannelInstallation201JSONResponse AppInstanceDetail
+
+func (response PutChannelInstallation201JSONResponse) VisitPutChannelInstallationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutChannelInstallationdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PutChannelInstallationdefaultJSONResponse) VisitPutChannelInstallationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type NotifyChannelRequestObject struct {
+	ChannelUUID openapi_types.UUID `json:"channelUUID"`
+	Body        *NotifyChannelJSONRequestBody
+}
+
+type NotifyChannelResponseObject interface {
+	VisitNotifyChannelResponse(w http.ResponseWriter) error
+}
+
+type NotifyChannel202Response struct {
 }
 
-type GetSessions200JSONResponse []SessionSummary
+func (response NotifyChannel202Response) VisitNotifyChannelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type NotifyChanneldefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response NotifyChanneldefaultJSONResponse) VisitNotifyChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteChannelRequestObject struct {
+	UUID openapi_types.UUID `json:"uuid"`
+}
+
+type DeleteChannelResponseObject interface {
+	VisitDeleteChannelResponse(w http.ResponseWriter) error
+}
+
+type DeleteChannel200Response struct {
+}
+
+func (response DeleteChannel200Response) VisitDeleteChannelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeleteChanneldefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response DeleteChanneldefaultJSONResponse) VisitDeleteChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type FindChannelByUUIDRequestObject struct {
+	UUID openapi_types.UUID `json:"uuid"`
+}
+
+type FindChannelByUUIDResponseObject interface {
+	VisitFindChannelByUUIDResponse(w http.ResponseWriter) error
+}
+
+type FindChannelByUUID200JSONResponse ChannelDetail
+
+func (response FindChannelByUUID200JSONResponse) VisitFindChannelByUUIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type FindChannelByUUIDdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response FindChannelByUUIDdefaultJSONResponse) VisitFindChannelByUUIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchChannelRequestObject struct {
+	UUID openapi_types.UUID `json:"uuid"`
+	Body *PatchChannelJSONRequestBody
+}
+
+type PatchChannelResponseObject interface {
+	VisitPatchChannelResponse(w http.ResponseWriter) error
+}
+
+type PatchChannel200Response struct {
+}
+
+func (response PatchChannel200Response) VisitPatchChannelResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PatchChanneldefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PatchChanneldefaultJSONResponse) VisitPatchChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDevicesRequestObject struct {
+	Params GetDevicesParams
+}
+
+type GetDevicesResponseObject interface {
+	VisitGetDevicesResponse(w http.ResponseWriter) error
+}
+
+type GetDevices200JSONResponse []DeviceSummary
+
+func (response GetDevices200JSONResponse) VisitGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDevicesdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetDevicesdefaultJSONResponse) VisitGetDevicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ApproveDeviceRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+}
+
+type ApproveDeviceResponseObject interface {
+	VisitApproveDeviceResponse(w http.ResponseWriter) error
+}
+
+type ApproveDevice200Response struct {
+}
+
+func (response ApproveDevice200Response) VisitApproveDeviceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ApproveDevicedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ApproveDevicedefaultJSONResponse) VisitApproveDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SendDeviceCommandRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+	Body       *SendDeviceCommandJSONRequestBody
+}
+
+type SendDeviceCommandResponseObject interface {
+	VisitSendDeviceCommandResponse(w http.ResponseWriter) error
+}
+
+type SendDeviceCommand202Response struct {
+}
+
+func (response SendDeviceCommand202Response) VisitSendDeviceCommandResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type SendDeviceCommanddefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response SendDeviceCommanddefaultJSONResponse) VisitSendDeviceCommandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDeviceConnectionsRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+}
+
+type GetDeviceConnectionsResponseObject interface {
+	VisitGetDeviceConnectionsResponse(w http.ResponseWriter) error
+}
+
+type GetDeviceConnections200JSONResponse []DeviceConnection
+
+func (response GetDeviceConnections200JSONResponse) VisitGetDeviceConnectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeviceConnectionsdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetDeviceConnectionsdefaultJSONResponse) VisitGetDeviceConnectionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type NotifyDeviceRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+	Body       *NotifyDeviceJSONRequestBody
+}
+
+type NotifyDeviceResponseObject interface {
+	VisitNotifyDeviceResponse(w http.ResponseWriter) error
+}
+
+type NotifyDevice202Response struct {
+}
+
+func (response NotifyDevice202Response) VisitNotifyDeviceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type NotifyDevicedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response NotifyDevicedefaultJSONResponse) VisitNotifyDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RejectDeviceRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+}
+
+type RejectDeviceResponseObject interface {
+	VisitRejectDeviceResponse(w http.ResponseWriter) error
+}
+
+type RejectDevice200Response struct {
+}
+
+func (response RejectDevice200Response) VisitRejectDeviceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type RejectDevicedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RejectDevicedefaultJSONResponse) VisitRejectDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RevokeDeviceTokenRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+}
+
+type RevokeDeviceTokenResponseObject interface {
+	VisitRevokeDeviceTokenResponse(w http.ResponseWriter) error
+}
+
+type RevokeDeviceToken200Response struct {
+}
+
+func (response RevokeDeviceToken200Response) VisitRevokeDeviceTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type RevokeDeviceTokendefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RevokeDeviceTokendefaultJSONResponse) VisitRevokeDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RotateDeviceTokenRequestObject struct {
+	DeviceUUID openapi_types.UUID `json:"deviceUUID"`
+}
+
+type RotateDeviceTokenResponseObject interface {
+	VisitRotateDeviceTokenResponse(w http.ResponseWriter) error
+}
+
+type RotateDeviceToken201JSONResponse DeviceToken
+
+func (response RotateDeviceToken201JSONResponse) VisitRotateDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateDeviceTokendefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RotateDeviceTokendefaultJSONResponse) VisitRotateDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeleteDeviceRequestObject struct {
+	UUID   openapi_types.UUID `json:"uuid"`
+	Params DeleteDeviceParams
+}
+
+type DeleteDeviceResponseObject interface {
+	VisitDeleteDeviceResponse(w http.ResponseWriter) error
+}
+
+type DeleteDevice200Response struct {
+}
+
+func (response DeleteDevice200Response) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeleteDevicedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response DeleteDevicedefaultJSONResponse) VisitDeleteDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetDeviceByUUIDRequestObject struct {
+	UUID openapi_types.UUID `json:"uuid"`
+}
+
+type GetDeviceByUUIDResponseObject interface {
+	VisitGetDeviceByUUIDResponse(w http.ResponseWriter) error
+}
+
+type GetDeviceByUUID200JSONResponse DeviceSummary
+
+func (response GetDeviceByUUID200JSONResponse) VisitGetDeviceByUUIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeviceByUUIDdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetDeviceByUUIDdefaultJSONResponse) VisitGetDeviceByUUIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchDeviceRequestObject struct {
+	UUID openapi_types.UUID `json:"uuid"`
+	Body *PatchDeviceJSONRequestBody
+}
+
+type PatchDeviceResponseObject interface {
+	VisitPatchDeviceResponse(w http.ResponseWriter) error
+}
+
+type PatchDevice200Response struct {
+}
+
+func (response PatchDevice200Response) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PatchDevicedefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PatchDevicedefaultJSONResponse) VisitPatchDeviceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetSessionsRequestObject struct {
+	Params GetSessionsParams
+}
+
+type GetSessionsResponseObject interface {
+	VisitGetSessionsResponse(w http.ResponseWriter) error
+}
+
+type GetSessions200JSONResponse []SessionSummary
+
+func (response GetSessions200JSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSessionsdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetSessionsdefaultJSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// List available apps
+	// (GET /applets)
+	GetApplets(ctx context.Context, request GetAppletsRequestObject) (GetAppletsResponseObject, error)
+	// Get the details of an app
+	// (GET /applets/{id})
+	GetAppletByID(ctx context.Context, request GetAppletByIDRequestObject) (GetAppletByIDResponseObject, error)
+
+	// (GET /channels)
+	GetChannels(ctx context.Context, request GetChannelsRequestObject) (GetChannelsResponseObject, error)
+
+	// (POST /channels)
+	CreateChannel(ctx context.Context, request CreateChannelRequestObject) (CreateChannelResponseObject, error)
+
+	// (POST /channels/{channelUUID}/applets)
+	CreateChannelApplet(ctx context.Context, request CreateChannelAppletRequestObject) (CreateChannelAppletResponseObject, error)
+
+	// (DELETE /channels/{channelUUID}/applets/{appletUUID})
+	DeleteChannelApplet(ctx context.Context, request DeleteChannelAppletRequestObject) (DeleteChannelAppletResponseObject, error)
+
+	// (PATCH /channels/{channelUUID}/applets/{appletUUID})
+	PatchChannelApplet(ctx context.Context, request PatchChannelAppletRequestObject) (PatchChannelAppletResponseObject, error)
+
+	// (DELETE /channels/{channelUUID}/applets/{appletUUID}/quarantine)
+	ClearAppletQuarantine(ctx context.Context, request ClearAppletQuarantineRequestObject) (ClearAppletQuarantineResponseObject, error)
+
+	// (PUT /channels/{channelUUID}/installations/{installationID})
+	PutChannelInstallation(ctx context.Context, request PutChannelInstallationRequestObject) (PutChannelInstallationResponseObject, error)
+
+	// (POST /channels/{channelUUID}/notifications)
+	NotifyChannel(ctx context.Context, request NotifyChannelRequestObject) (NotifyChannelResponseObject, error)
+
+	// (DELETE /channels/{uuid})
+	DeleteChannel(ctx context.Context, request DeleteChannelRequestObject) (DeleteChannelResponseObject, error)
+
+	// (GET /channels/{uuid})
+	FindChannelByUUID(ctx context.Context, request FindChannelByUUIDRequestObject) (FindChannelByUUIDResponseObject, error)
+
+	// (PATCH /channels/{uuid})
+	PatchChannel(ctx context.Context, request PatchChannelRequestObject) (PatchChannelResponseObject, error)
+	// Get configured devices
+	// (GET /devices)
+	GetDevices(ctx context.Context, request GetDevicesRequestObject) (GetDevicesResponseObject, error)
+
+	// (POST /devices/{deviceUUID}/approve)
+	ApproveDevice(ctx context.Context, request ApproveDeviceRequestObject) (ApproveDeviceResponseObject, error)
+
+	// (POST /devices/{deviceUUID}/commands)
+	SendDeviceCommand(ctx context.Context, request SendDeviceCommandRequestObject) (SendDeviceCommandResponseObject, error)
+
+	// (GET /devices/{deviceUUID}/connections)
+	GetDeviceConnections(ctx context.Context, request GetDeviceConnectionsRequestObject) (GetDeviceConnectionsResponseObject, error)
+
+	// (POST /devices/{deviceUUID}/notifications)
+	NotifyDevice(ctx context.Context, request NotifyDeviceRequestObject) (NotifyDeviceResponseObject, error)
+
+	// (POST /devices/{deviceUUID}/reject)
+	RejectDevice(ctx context.Context, request RejectDeviceRequestObject) (RejectDeviceResponseObject, error)
+
+	// (DELETE /devices/{deviceUUID}/token)
+	RevokeDeviceToken(ctx context.Context, request RevokeDeviceTokenRequestObject) (RevokeDeviceTokenResponseObject, error)
+
+	// (POST /devices/{deviceUUID}/token)
+	RotateDeviceToken(ctx context.Context, request RotateDeviceTokenRequestObject) (RotateDeviceTokenResponseObject, error)
+
+	// (DELETE /devices/{uuid})
+	DeleteDevice(ctx context.Context, request DeleteDeviceRequestObject) (DeleteDeviceResponseObject, error)
+
+	// (GET /devices/{uuid})
+	GetDeviceByUUID(ctx context.Context, request GetDeviceByUUIDRequestObject) (GetDeviceByUUIDResponseObject, error)
+
+	// (PATCH /devices/{uuid})
+	PatchDevice(ctx context.Context, request PatchDeviceRequestObject) (PatchDeviceResponseObject, error)
+	// Get connected sessions
+	// (GET /sessions)
+	GetSessions(ctx context.Context, request GetSessionsRequestObject) (GetSessionsResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetApplets operation middleware
+func (sh *strictHandler) GetApplets(w http.ResponseWriter, r *http.Request, params GetAppletsParams) {
+	var request GetAppletsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApplets(ctx, request.(GetAppletsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApplets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAppletsResponseObject); ok {
+		if err := validResponse.VisitGetAppletsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAppletByID operation middleware
+func (sh *strictHandler) GetAppletByID(w http.ResponseWriter, r *http.Request, id string) {
+	var request GetAppletByIDRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAppletByID(ctx, request.(GetAppletByIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAppletByID")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAppletByIDResponseObject); ok {
+		if err := validResponse.VisitGetAppletByIDResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetChannels operation middleware
+func (sh *strictHandler) GetChannels(w http.ResponseWriter, r *http.Request) {
+	var request GetChannelsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChannels(ctx, request.(GetChannelsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChannels")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetChannelsResponseObject); ok {
+		if err := validResponse.VisitGetChannelsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateChannel operation middleware
+func (sh *strictHandler) CreateChannel(w http.ResponseWriter, r *http.Request) {
+	var request CreateChannelRequestObject
+
+	var body CreateChannelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateChannel(ctx, request.(CreateChannelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateChannel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateChannelResponseObject); ok {
+		if err := validResponse.VisitCreateChannelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateChannelApplet operation middleware
+func (sh *strictHandler) CreateChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID) {
+	var request CreateChannelAppletRequestObject
+
+	request.ChannelUUID = channelUUID
+
+	var body CreateChannelAppletJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateChannelApplet(ctx, request.(CreateChannelAppletRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateChannelApplet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateChannelAppletResponseObject); ok {
+		if err := validResponse.VisitCreateChannelAppletResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteChannelApplet operation middleware
+func (sh *strictHandler) DeleteChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID) {
+	var request DeleteChannelAppletRequestObject
+
+	request.ChannelUUID = channelUUID
+	request.AppletUUID = appletUUID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteChannelApplet(ctx, request.(DeleteChannelAppletRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteChannelApplet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteChannelAppletResponseObject); ok {
+		if err := validResponse.VisitDeleteChannelAppletResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PatchChannelApplet operation middleware
+func (sh *strictHandler) PatchChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID) {
+	var request PatchChannelAppletRequestObject
+
+	request.ChannelUUID = channelUUID
+	request.AppletUUID = appletUUID
+
+	var body PatchChannelAppletJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchChannelApplet(ctx, request.(PatchChannelAppletRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchChannelApplet")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PatchChannelAppletResponseObject); ok {
+		if err := validResponse.VisitPatchChannelAppletResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ClearAppletQuarantine operation middleware
+func (sh *strictHandler) ClearAppletQuarantine(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID) {
+	var request ClearAppletQuarantineRequestObject
+
+	request.ChannelUUID = channelUUID
+	request.AppletUUID = appletUUID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ClearAppletQuarantine(ctx, request.(ClearAppletQuarantineRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ClearAppletQuarantine")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ClearAppletQuarantineResponseObject); ok {
+		if err := validResponse.VisitClearAppletQuarantineResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PutChannelInstallation operation middleware
+func (sh *strictHandler) PutChannelInstallation(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, installationID string) {
+	var request PutChannelInstallationRequestObject
+
+	request.ChannelUUID = channelUUID
+	request.InstallationID = installationID
 
-func (response GetSessions200JSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var body PutChannelInstallationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutChannelInstallation(ctx, request.(PutChannelInstallationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutChannelInstallation")
+	}
 
-type GetSessionsdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutChannelInstallationResponseObject); ok {
+		if err := validResponse.VisitPutChannelInstallationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response GetSessionsdefaultJSONResponse) VisitGetSessionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// NotifyChannel operation middleware
+func (sh *strictHandler) NotifyChannel(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID) {
+	var request NotifyChannelRequestObject
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	request.ChannelUUID = channelUUID
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// List available apps
-	// (GET /applets)
-	GetApplets(ctx context.Context, request GetAppletsRequestObject) (GetAppletsResponseObject, error)
-	// Get the details of an app
-	// (GET /applets/{id})
-	GetAppletByID(ctx context.Context, request GetAppletByIDRequestObject) (GetAppletByIDResponseObject, error)
+	var body NotifyChannelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-	// (GET /channels)
-	GetChannels(ctx context.Context, request GetChannelsRequestObject) (GetChannelsResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.NotifyChannel(ctx, request.(NotifyChannelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "NotifyChannel")
+	}
 
-	// (POST /channels)
-	CreateChannel(ctx context.Context, request CreateChannelRequestObject) (CreateChannelResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (POST /channels/{channelUUID}/applets)
-	CreateChannelApplet(ctx context.Context, request CreateChannelAppletRequestObject) (CreateChannelAppletResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(NotifyChannelResponseObject); ok {
+		if err := validResponse.VisitNotifyChannelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
 
-	// (DELETE /channels/{channelUUID}/applets/{appletUUID})
-	DeleteChannelApplet(ctx context.Context, request DeleteChannelAppletRequestObject) (DeleteChannelAppletResponseObject, error)
+// DeleteChannel operation middleware
+func (sh *strictHandler) DeleteChannel(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request DeleteChannelRequestObject
 
-	// (PATCH /channels/{channelUUID}/applets/{appletUUID})
-	PatchChannelApplet(ctx context.Context, request PatchChannelAppletRequestObject) (PatchChannelAppletResponseObject, error)
+	request.UUID = uuid
 
-	// (GET /channels/{uuid})
-	FindChannelByUUID(ctx context.Context, request FindChannelByUUIDRequestObject) (FindChannelByUUIDResponseObject, error)
-	// Get configured devices
-	// (GET /devices)
-	GetDevices(ctx context.Context, request GetDevicesRequestObject) (GetDevicesResponseObject, error)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteChannel(ctx, request.(DeleteChannelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteChannel")
+	}
 
-	// (GET /devices/{uuid})
-	GetDeviceByUUID(ctx context.Context, request GetDeviceByUUIDRequestObject) (GetDeviceByUUIDResponseObject, error)
+	response, err := handler(r.Context(), w, r, request)
 
-	// (PATCH /devices/{uuid})
-	PatchDevice(ctx context.Context, request PatchDeviceRequestObject) (PatchDeviceResponseObject, error)
-	// Get connected sessions
-	// (GET /sessions)
-	GetSessions(ctx context.Context, request GetSessionsRequestObject) (GetSessionsResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteChannelResponseObject); ok {
+		if err := validResponse.VisitDeleteChannelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// FindChannelByUUID operation middleware
+func (sh *strictHandler) FindChannelByUUID(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request FindChannelByUUIDRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.UUID = uuid
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.FindChannelByUUID(ctx, request.(FindChannelByUUIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FindChannelByUUID")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(FindChannelByUUIDResponseObject); ok {
+		if err := validResponse.VisitFindChannelByUUIDResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// GetApplets operation middleware
-func (sh *strictHandler) GetApplets(w http.ResponseWriter, r *http.Request, params GetAppletsParams) {
-	var request GetAppletsRequestObject
+// PatchChannel operation middleware
+func (sh *strictHandler) PatchChannel(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
+	var request PatchChannelRequestObject
 
-	request.Params = params
+	request.UUID = uuid
+
+	var body PatchChannelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetApplets(ctx, request.(GetAppletsRequestObject))
+		return sh.ssi.PatchChannel(ctx, request.(PatchChannelRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetApplets")
+		handler = middleware(handler, "PatchChannel")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetAppletsResponseObject); ok {
-		if err := validResponse.VisitGetAppletsResponse(w); err != nil {
+	} else if validResponse, ok := response.(PatchChannelResponseObject); ok {
+		if err := validResponse.VisitPatchChannelResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1136,25 +2707,25 @@ func (sh *strictHandler) GetApplets(w http.ResponseWriter, r *http.Request, para
 	}
 }
 
-// GetAppletByID operation middleware
-func (sh *strictHandler) GetAppletByID(w http.ResponseWriter, r *http.Request, id string) {
-	var request GetAppletByIDRequestObject
+// GetDevices operation middleware
+func (sh *strictHandler) GetDevices(w http.ResponseWriter, r *http.Request, params GetDevicesParams) {
+	var request GetDevicesRequestObject
 
-	request.Id = id
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAppletByID(ctx, request.(GetAppletByIDRequestObject))
+		return sh.ssi.GetDevices(ctx, request.(GetDevicesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAppletByID")
+		handler = middleware(handler, "GetDevices")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetAppletByIDResponseObject); ok {
-		if err := validResponse.VisitGetAppletByIDResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetDevicesResponseObject); ok {
+		if err := validResponse.VisitGetDevicesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1162,23 +2733,25 @@ func (sh *strictHandler) GetAppletByID(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
-// GetChannels operation middleware
-func (sh *strictHandler) GetChannels(w http.ResponseWriter, r *http.Request) {
-	var request GetChannelsRequestObject
+// ApproveDevice operation middleware
+func (sh *strictHandler) ApproveDevice(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request ApproveDeviceRequestObject
+
+	request.DeviceUUID = deviceUUID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetChannels(ctx, request.(GetChannelsRequestObject))
+		return sh.ssi.ApproveDevice(ctx, request.(ApproveDeviceRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetChannels")
+		handler = middleware(handler, "ApproveDevice")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetChannelsResponseObject); ok {
-		if err := validResponse.VisitGetChannelsResponse(w); err != nil {
+	} else if validResponse, ok := response.(ApproveDeviceResponseObject); ok {
+		if err := validResponse.VisitApproveDeviceResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1186,11 +2759,13 @@ func (sh *strictHandler) GetChannels(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateChannel operation middleware
-func (sh *strictHandler) CreateChannel(w http.ResponseWriter, r *http.Request) {
-	var request CreateChannelRequestObject
+// SendDeviceCommand operation middleware
+func (sh *strictHandler) SendDeviceCommand(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request SendDeviceCommandRequestObject
 
-	var body CreateChannelJSONRequestBody
+	request.DeviceUUID = deviceUUID
+
+	var body SendDeviceCommandJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1198,18 +2773,18 @@ func (sh *strictHandler) CreateChannel(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateChannel(ctx, request.(CreateChannelRequestObject))
+		return sh.ssi.SendDeviceCommand(ctx, request.(SendDeviceCommandRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateChannel")
+		handler = middleware(handler, "SendDeviceCommand")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateChannelResponseObject); ok {
-		if err := validResponse.VisitCreateChannelResponse(w); err != nil {
+	} else if validResponse, ok := response.(SendDeviceCommandResponseObject); ok {
+		if err := validResponse.VisitSendDeviceCommandResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1217,13 +2792,39 @@ func (sh *strictHandler) CreateChannel(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// CreateChannelApplet operation middleware
-func (sh *strictHandler) CreateChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID) {
-	var request CreateChannelAppletRequestObject
+// GetDeviceConnections operation middleware
+func (sh *strictHandler) GetDeviceConnections(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request GetDeviceConnectionsRequestObject
 
-	request.ChannelUUID = channelUUID
+	request.DeviceUUID = deviceUUID
 
-	var body CreateChannelAppletJSONRequestBody
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDeviceConnections(ctx, request.(GetDeviceConnectionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDeviceConnections")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDeviceConnectionsResponseObject); ok {
+		if err := validResponse.VisitGetDeviceConnectionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// NotifyDevice operation middleware
+func (sh *strictHandler) NotifyDevice(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request NotifyDeviceRequestObject
+
+	request.DeviceUUID = deviceUUID
+
+	var body NotifyDeviceJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1231,18 +2832,18 @@ func (sh *strictHandler) CreateChannelApplet(w http.ResponseWriter, r *http.Requ
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateChannelApplet(ctx, request.(CreateChannelAppletRequestObject))
+		return sh.ssi.NotifyDevice(ctx, request.(NotifyDeviceRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateChannelApplet")
+		handler = middleware(handler, "NotifyDevice")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CreateChannelAppletResponseObject); ok {
-		if err := validResponse.VisitCreateChannelAppletResponse(w); err != nil {
+	} else if validResponse, ok := response.(NotifyDeviceResponseObject); ok {
+		if err := validResponse.VisitNotifyDeviceResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1250,26 +2851,25 @@ func (sh *strictHandler) CreateChannelApplet(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// DeleteChannelApplet operation middleware
-func (sh *strictHandler) DeleteChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID) {
-	var request DeleteChannelAppletRequestObject
+// RejectDevice operation middleware
+func (sh *strictHandler) RejectDevice(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request RejectDeviceRequestObject
 
-	request.ChannelUUID = channelUUID
-	request.AppletUUID = appletUUID
+	request.DeviceUUID = deviceUUID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteChannelApplet(ctx, request.(DeleteChannelAppletRequestObject))
+		return sh.ssi.RejectDevice(ctx, request.(RejectDeviceRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteChannelApplet")
+		handler = middleware(handler, "RejectDevice")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(DeleteChannelAppletResponseObject); ok {
-		if err := validResponse.VisitDeleteChannelAppletResponse(w); err != nil {
+	} else if validResponse, ok := response.(RejectDeviceResponseObject); ok {
+		if err := validResponse.VisitRejectDeviceResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1277,33 +2877,25 @@ func (sh *strictHandler) DeleteChannelApplet(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// PatchChannelApplet operation middleware
-func (sh *strictHandler) PatchChannelApplet(w http.ResponseWriter, r *http.Request, channelUUID openapi_types.UUID, appletUUID openapi_types.UUID) {
-	var request PatchChannelAppletRequestObject
-
-	request.ChannelUUID = channelUUID
-	request.AppletUUID = appletUUID
+// RevokeDeviceToken operation middleware
+func (sh *strictHandler) RevokeDeviceToken(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request RevokeDeviceTokenRequestObject
 
-	var body PatchChannelAppletJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.DeviceUUID = deviceUUID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchChannelApplet(ctx, request.(PatchChannelAppletRequestObject))
+		return sh.ssi.RevokeDeviceToken(ctx, request.(RevokeDeviceTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchChannelApplet")
+		handler = middleware(handler, "RevokeDeviceToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PatchChannelAppletResponseObject); ok {
-		if err := validResponse.VisitPatchChannelAppletResponse(w); err != nil {
+	} else if validResponse, ok := response.(RevokeDeviceTokenResponseObject); ok {
+		if err := validResponse.VisitRevokeDeviceTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1311,25 +2903,25 @@ func (sh *strictHandler) PatchChannelApplet(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// FindChannelByUUID operation middleware
-func (sh *strictHandler) FindChannelByUUID(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID) {
-	var request FindChannelByUUIDRequestObject
+// RotateDeviceToken operation middleware
+func (sh *strictHandler) RotateDeviceToken(w http.ResponseWriter, r *http.Request, deviceUUID openapi_types.UUID) {
+	var request RotateDeviceTokenRequestObject
 
-	request.UUID = uuid
+	request.DeviceUUID = deviceUUID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.FindChannelByUUID(ctx, request.(FindChannelByUUIDRequestObject))
+		return sh.ssi.RotateDeviceToken(ctx, request.(RotateDeviceTokenRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "FindChannelByUUID")
+		handler = middleware(handler, "RotateDeviceToken")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(FindChannelByUUIDResponseObject); ok {
-		if err := validResponse.VisitFindChannelByUUIDResponse(w); err != nil {
+	} else if validResponse, ok := response.(RotateDeviceTokenResponseObject); ok {
+		if err := validResponse.VisitRotateDeviceTokenResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1337,23 +2929,26 @@ func (sh *strictHandler) FindChannelByUUID(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// GetDevices operation middleware
-func (sh *strictHandler) GetDevices(w http.ResponseWriter, r *http.Request) {
-	var request GetDevicesRequestObject
+// DeleteDevice operation middleware
+func (sh *strictHandler) DeleteDevice(w http.ResponseWriter, r *http.Request, uuid openapi_types.UUID, params DeleteDeviceParams) {
+	var request DeleteDeviceRequestObject
+
+	request.UUID = uuid
+	request.Params = params
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetDevices(ctx, request.(GetDevicesRequestObject))
+		return sh.ssi.DeleteDevice(ctx, request.(DeleteDeviceRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetDevices")
+		handler = middleware(handler, "DeleteDevice")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetDevicesResponseObject); ok {
-		if err := validResponse.VisitGetDevicesResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteDeviceResponseObject); ok {
+		if err := validResponse.VisitDeleteDeviceResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1421,9 +3016,11 @@ func (sh *strictHandler) PatchDevice(w http.ResponseWriter, r *http.Request, uui
 }
 
 // GetSessions operation middleware
-func (sh *strictHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+func (sh *strictHandler) GetSessions(w http.ResponseWriter, r *http.Request, params GetSessionsParams) {
 	var request GetSessionsRequestObject
 
+	request.Params = params
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
 		return sh.ssi.GetSessions(ctx, request.(GetSessionsRequestObject))
 	}
@@ -1447,35 +3044,98 @@ func (sh *strictHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xZS2/bOBD+KwR3j06cPg6Fbkm8DQy0TVAjvRQ90NLYZiuRLEk5MQL99wUfsvWgLDlr",
-	"p93FnuKI5HA43zfDmeETjnkmOAOmFY6esAQlOFNg/5nAguSp/ktKLj/7AfM95kwD0+YnESKlMdGUs/F3",
-	"xZn5puIVZMT8+lPCAkf4j/Fuk7EbVWMrFRdFMcIJqFhSYYTgCN+zH4w/MAR+wsgLtCpdCmH+CMkFSE2d",
-	"niTXKy7Nr7qkS/sd8QXSK0BECDzCeiMAR1hpSdkSNzd3ErYCPnC2RAsuMyPjYUU00iuqjKQUNEo4qJBE",
-	"mrRVuWf0Zw5oOunRhpEM2qs/kQx6Fg4z+szNMvPzLCNy095rtuJSIz+8d9NihCX8zKmEBEdfzbG9/jvp",
-	"dfOOSqS+bWXx+XeItVHoUogpU5qwGCagCU0tsml6u8DR1/2nqiyd+Y2LUZMkeR7C5VIIRP1adH8/neAR",
-	"NoATjSO3pHnsEX48W/IzBxS2S4qi6DnRbGftBnWFOOvQy1DM6rNvf7PHxGwWc7agy05BbjiXxAOxPaN1",
-	"2iCJH8PWElxRL8QvokzDEmSLEP5sIdNcrwhjkB4KtF/WDbLzTPuTashUnz+0SVdstSVSko1zlbkxwhzk",
-	"cMETWNMYPsOiLTBMFn80s6JFkv6gELvVISDDvDe8bS9+BvO7j9LJ+Zhnmb8+6kpduwETcftO9ctMIoEk",
-	"tyzd4EjLHAaaqOoVdizEgB1lXpoAid35aPi7g1TgH+bcVZdpUcYfLxoUH6yMsJu5rCNAySRgYDsZ2bGK",
-	"bSjTb14Hot8IZ6AUWXYKKof7LlK/YTk9dIxPXNOFz7uGW9jd/u8ppAluW8fDW+pm557XNqrMOKOZ4FLv",
-	"yFkmICMsiF7hCGuazDf6PIH1WNDHFLRXwx53ts1W6jgsjG7Do2ztQO3AzSrKDxdaO3JA6hqkqiSLO5S/",
-	"+IE+fEsBwxCYlXY9ru2d0VoAJC7lbx/OD6A1SXMIJgxxyCb268AcORByOiO9zzNbs7k4DGxnjFvRBbb7",
-	"v7m7/RrYfU0VndOU6s2wfb/s5rcj6R42OPCOTYnbbRHU4ARVIiWBUqEcCFhCw2OAROayUebWIgrtWew4",
-	"1lrdQb2Gd9mdSxmHuNitOEmQ+1LjRPPaYQnVwWCyGwpZ9zBWHmROM19SMk+DS/xILwhOleohtmsPwaRi",
-	"vCPiAsrE3+789PBkw9S5Noc6pCwIxUCvG2oUoh05Rz0jc4WghIxrOCNJEuiIfLaDaHqHzDgoFUzy+BJY",
-	"KdStuDTSAsme+UTZggdKxbsp0hxlhJEloDv6COlHoiV9RArkmoJEhCVlELBqUG0oh+1UdEM0PNjosL1u",
-	"8avzi/MLF+SBEUFxhN/YTw52i924UgEuQYcsoHPJEElTlzf7uhgSRIQwehgm2Lt/muAI34C+9BLNLpJk",
-	"oG0d+LUpeJqY8y5oqkGiudGcms8/c7B9EG9Oe8ftejVNL/o2qnfgXl9cHNRwG1r0hqrSUaDYL5VxDN+m",
-	"BiHpW73HwbZhUe054Q9UaUTWhKYmJDjTmxklfOMnmhQ9GCpEmFmJ5htkzdqB3NXGOtN+8Kp9OYsj6HhV",
-	"YmiDSh3CXbBzZeDpIO1Fso0caSD39uLt6du2n7hG73nOkmNz5Qa0r1I1oakyQDngHWN8tFa9bDEyUsM7",
-	"vijLZXUeos11KfElnLHV0ur1S7/iqL5ZjLDgKtSSkUA0IIIYPFSaDHWbuUnX21HjHKD0FU82R+Nd004B",
-	"AtY0rDto0YLy1bE1K/uHAcCseY7iFzXGj5/8r/v76aSo3nwDsPRvGGXvez+mLpD2BdFwAy0QQStq7w2l",
-	"+9tRPrQen2qh54Tg9ehs8nJMC3Sr97Lt7RGvms64f0US5FH4d941A3xq/OR+2I/OsVLQgQJpYr/7+2mv",
-	"f7mZv7d/jfZp0D5gQJOd2f65o7dv4rpytz+OdRMSk/u15H/kCV1shmB7ZwT8D+2pY3irl/I7PIAGCuRw",
-	"+ka0lnSea1AD7pDT0b0e/AxI3XVXKBPvTArfU5b4s15tPE0O9YGeSmz7JPdM8oUetE5ZrvVniqdI7Q3C",
-	"rjtVrZFaVc/ET3mJoqf+Qjig5nELTtaOuKkECEhQaa2q6Z7lG9uH1Q5rD3OMwEPtf8AZGhx4Ecz7L/cu",
-	"yOyVPinHBsexXwLYUW7T5/TAw091Hsfwi92QG9ML+I0uTOW69Hvj6ayc8xIBtfGkMSCilg8NJw6pDGIN",
-	"CdoazGqiQK5L38lliiO80lpE43HKY5KuuNLRu4t3F2MiKC6+FX8HAAD//03xeaWkKgAA",
+	"H4sIAAAAAAAC/+x9bW8bt7LwXyH2PEBbQJadNu3T6/vJjZvUQJP42ukpip7ggNodSax3yS3JtaIb6L9f",
+	"zJDcV0papXLi4uRTUy1fhjPDeef4fZKqolQSpDXJ+ftEgymVNED/cwlzXuX2R62VvvEf8PdUSQvS4j95",
+	"WeYi5VYoefqHURJ/M+kSCo7/+n8a5sl58o/TZpNT99Wc0qrJZrOZJBmYVIsSF0nOk1/knVQrycAPmPgF",
+	"CaSLssT/lFqVoK1wcPLKLpXGf3VXuqDfmZozuwTGyzKZJHZdQnKeGKuFXCT9zd0K4f+Sn5VcsLnSBa6x",
+	"WnLL7FIYXCkHyzIFJraiyIag/CLFnxWwq8s90EhewHD2K17AnonjkH7rRuH4qii4Xg/3ul0qbZn/vHPT",
+	"zSTR8GclNGTJ+e94bA9/s3oXvZNAqbf1Wmr2B6QWAbooyytpLJcpXILlIifK5vnreXL+++5Ttabe+o03",
+	"kz6TGMttZfYh6IJIe+vGbiZJVcWoeVGWTPgd2S+/XF0mkwTZhNvk3E3pI2uSvDtZqBNH3oSmbDabPXi4",
+	"bWjUY/iyPNkCFzLm1eWEQVHaNfIuEwVfgNkDEe56idunSs7FYuvS7nOluSdofWq6/LHrJUyZ8/WJFTHG",
+	"voVUycwwq5gfGNgtBzthlTRg8WNlgD6kSy4l5CxzkqnZUEgLC9C9U126Nd/g3nQx38VpWSoj/IF66+Es",
+	"pEae04mjWL+6ZFw6NLMVN8xPgIxVMiOYNPDstczXybnVFeymxFVrO0eSOyEjuwYcBQoTrTkrhZSQsTbQ",
+	"ewGgizzXYJYneG59z/OdpNKA9OBMA56PzWCuNDBdSSnkokVAxhdcyCn7JZDRT4B70GumlSXwkMw5GNOe",
+	"aMAaJqxhqAgK/u7ffAHTf8l99L5xp7gKh6CD4ZbEfqqyO47VbF7wNR4mnEtYhjJ/xmWmJGQRrjSg70GP",
+	"ZcobguiNB8iL7qzKYaRsCqP78tcLhS0ypT1zgIRflyDbGBDIxDWBpuxHIlguDCpATt8RBUVlEFs2Xf43",
+	"3gAncsJpGM9XfG2YWapVG72OjF15lnELZgjWJbfANJdOfAkLhRmj4HD3S1pxU+OCa81RKyQZX0d3Wpug",
+	"61YAdxNWKMSIVtViyUwl2/sPLk9/j5WQmVpFtkGi0z4ZXx96ol9p0eF2mz69PbcFKP30aU391vcTUZRK",
+	"063wzGmaUSW3y+Q8WQi7rGbTVBWnfyj4/0+enpbiHeSL1SnJCsnzU9NmyY4GHWDAMT+bc5FXGhgqZZgw",
+	"PjMgLRPzNhMuuZFfWLqJRqCibV01Y7m2kA0Yya8b2fiZkgbSyop7oN0h89LIRIV+zo09cRboYCmyXNlc",
+	"q4JAKpSxTEOKJ/D771G2P3Njvfnrdwrzzt83GhUvhVOb+1d77ueH9UyVpmDMB6936+dvJsmfFddcWiEh",
+	"ooTedGSGVCxXcgGaiFZJK3IS4s0SOCrNgWtomUgzpXLg0klrq9cn3O7bydyJsoTM70FGOZ6M8bkF1IIN",
+	"HQ4+/g2CcGEjF2szSZ45++NQA9VP226cupPRP0cJhaGxHBFDppohBmegxy98CfcihRuYRwRN1Fz1R/tZ",
+	"OScwqlt0x3j7wrCMdjGMa5g4u8WrfS9IzJRdBH2S+5VR25qWDPjCk/x/lQTGZYYGJJ/lYFBca2Hcj4aU",
+	"dUznoG1kq6x351Q1y1scIqti5gWCkotDxiNsCFrEXLx4ddGAPmGmSpeMG3ZRgBYpP30Fq3//pvRd1OHa",
+	"hn+k2MBN2O9MepLEDPe454Oey3DyB/g+24+y3euRSq4LFVMrF3muVqwewO4FrECbCforElJLdqlipyvD",
+	"VsIuO76EO5Jm/0AAyXAGnuEZuefTf8morEpVUfgoSF/V0Adi7D1I3u0d+fgLM0MvCXi69LfmQCdozvN8",
+	"xtO72E3lZNaixcZWaBJKFW5myqXXl+dM4o378g6gpOOhwnEuyFcTlgm+kMpYkbIvA4IJr3gZSZ9+RS6L",
+	"X/ZLVBQBohNnwX41Zf7g/sRhxY4D0OCwN32HV4zL4YZ0tjCN1QGdPYz73E+oXeV6420+87O2s1yzQ71x",
+	"vetuJzoOhVubwBD2hEtRbJG/P76zIDPa2TsojauNQsgqhnogBwusXoblSpVmyPY9WIS9qDcmGdkogRHq",
+	"sNYZo+JeDySqDnHNa9HVdrvoW0wzOlWKwoDLbCjNZlosllZ6K60L+g/1N1aCTkHa4ODjYZqZLPWrx0zY",
+	"tNm5u3prvtJMw0wpuze6F1bbdVInapUcHtaLYWdCjjDJnGjszOrdaecxrJYiB1aPc9GQeddTUGQpzoU2",
+	"dpw9iHdK8yLmRVxRLI3R3lmlQ7jDgDG9eFglpP3uaZQwoouFETrTyRvnKWh/Uz7MT6DZRNpCWTjhWaaH",
+	"Lm3fIMahFzgSjUp31JP+IYS033y9TxXdusnbDADHR9daWZWqfJuCIkGGA5kG9F4hYwopjR9SJa1WOSv9",
+	"Gm3XUtQupeEiY0vIczWwClNe8pnIRfj/8U7/XOhixXVEij33X9g9aCPiYdIl4J1sbdT3RaMa+xb+rECm",
+	"wJzdGaQdTmDEKG1sealPntdeNh2yzkV61wamNlk+iA8v0rtgjxT83Ulz3XaC8ZK/e+5GbiZJQOZQacBC",
+	"WcGRLwIbDDHfQu9KZHYZw/x2Fv0U1raj4tGMbXeQlq09zpNt+4d/WaPVtxM127zK85Zqi6s0j8Bx9oWD",
+	"stE9JxRGitwiy7UNaFZ5BsayHONEEbE+lsmfhU1vaU/SZ0WB40Z74m78DcXVIuLGQxTVUMx9ZQYsuj4d",
+	"PLcMwRgjuvvt1UJP52aZDnF6L1NwdKN+KSY25vZ7XUJ7GYDIJf65tfCENbYAo8gb3ik80IQZfg+ZTygU",
+	"QlYWcIRRH0IzCnwBkD2qZC5kPFJul6DbOFhyw3ifYYbuYskF7nmSqgxirkIG5HhJpmTtebISZIZGBi9L",
+	"rTCjsfsE124PXAy3pOhqNHOk1T1aS2F5MgP/IBrGeMKqO5AnwpgKsh3JAwfzF4alldbIbjTPpcTc3A8g",
+	"yhtc48pNr0FpDNMR9KEsRamBbgD3QJHXQxy1z8khAG7Chiiqy925TBes7kudoTiLx9OcgKVNhyrGhp8H",
+	"O2voWEb+uMa5uqK+Smavne+2iELWEki75LuzyRGYOhWELnZqxT1MGcZxyRb3vzCNA1ZCmim7rST5pBQY",
+	"ZN7Wq+N/at7jMiflY7G9XZro2qmfCTtjttLSizPvEav5PKp4jpSi66i3es23uzM4WaUxrjlt439nDsdP",
+	"OCiFE+ZsiNCEjGut5iKeLeQ+4OM9IavaXhARz8WKUCUo/SHk6bhwKc/BMILZsNl6wp6cnTnO7pi3BaWM",
+	"IY+TcLfCBJmqzEmfg2zzrvXa02GYC0FGr6MqxjMe6mFMlohC7A3fdYzeg2zVH0P6qu+LxxQQDWb0rcFV",
+	"ssWrw4ODMXyxdaHweX9YgTYMw2NyhwjUrooYHila2HHtizqYcHzS1BukqpIUEqYc3tmUvS6ERSmpNOX0",
+	"T54gS/MsY5xJWPn6Ci+PQLowA8U/uWTwThhazI0SkpU5T321QiGkKKoiOT95EkMiTYkIU27gu6eOJyFj",
+	"v8LsesJeXD3Hba9fvXA7tck0W9v9qBbFNgy/UlbMRRO7G+cKuFqy5wLyLNm83Z+FLvi0s9HePHTBGxFm",
+	"RTZb22kG9yi7crAeDDple1nU1GAi9/wilOg4K4svfZQfaSoRkXmLQ0CQFVGVueJIAqVbEg2ZhstQzsBu",
+	"wTIlyclzG7jw9onIpuyCLcUCVyq1UFrYNZMtWBmJX12VlixItUIXXkJMo+2Jbde1NZ+szCuM3VU3RIkF",
+	"YV3yAhX9JHgi9PnJt8nHvyQ7Q88op3O1WGxRDIGow9k/daguwDSkbuhsusc/i55eQwnRfDjZSQ1Se6iM",
+	"2ZuRm98tlxnqRpnmlUErLatLcSbst99+++3k5cuTy8th/QU6gNFomYr83BNQwXtUyduRNS0I9g0C9dBF",
+	"Lb0inCg1fF2Pw1JQOk3Ou874Ttmb2sb96afzly8ndcZaaZ+wnrg0JZdM0RY8R9PUgK2zxW7cyTdnBdnN",
+	"vYQ3kwBZD4CQ8KZhdTobZZSrV3KlXYB3tal8o0Ibg1kzw0r0yQuRSTSHYkIKXLZhQHxaZD/93bAJLTOW",
+	"AzxBPgL5XXF1j91R+Y0vruhozGFIp60axi/a0amRVbfGSf+5LRrdI0tYYJyKvw2K+1jKvY20AQFCIGtw",
+	"OP+B3fO8ikp9kcZwQr+OLOkX2XhtQr9GRrvbfSgHvS63Edv9f393+jWy+70wgpId63H7/rMZv9kcwA2O",
+	"eMdmiddl3BnwvnyEJ/yHCCYsvIswEfmgVLxg2I7JjscGs7ewXj/egjuHNQ65Yq/LI1vRAxrHsriZiFt5",
+	"zafJtlTZaK48CJ04XguKXUSm+C97ieBAaR+innsITVrIOyJdXFjlg8qi5KAmqpeL8oaGVE1+KVLp9JfS",
+	"Lgek/B0Ih5Qoti77zjndkBZO1FQXEMnWUrCFaSA3PmOztYsCQEg8eOPILmGNkYQ8YzOXjTg8r3rpgdii",
+	"YTzlWe990ahUu3PzfIA/Xk7rA2fCML7iwrbzDBNmFBOULKd0xCBz2U5stPL1++lWZ/eHRQj9SnH8yK6u",
+	"GXd5p6hrqxYgY4UKQ2cHfxJyriK35PoKfaaCS/Ter9ESfMmtFu8oAClAh/JSZCECQ9ic0i04lL3gFlak",
+	"GWpTK3kyPZueOQUPkpciOU++oZ/claeretoq+l2AjWEAA9SM53mobSCfHDIkE8KBUsC9E8qS8+QF2Au/",
+	"Iu6CvGqp9Pf3gVuX4XnnIrf4egchF/jznxXQkz2PTrJvmmeFfQn6dtJ9LPr12dlBb0PH1jnHCpEnkTdc",
+	"ARgnSGqzMLZ6Dfdp9IXrpv08MvlZGMv4PRc5qgOHehwRyHf6XmSbPTQ0Pl6EwkRk2yn3w/rqci/x2k9I",
+	"iY5g02WgISmULgkbRecq3B6OpHspOaQc71Hu6dnTh39h/EpZ9lxVMjs2r7yos3CWi5xe+jjCO47xUtTs",
+	"5RZcg15bqXkQvWYaY5tnYcWPcRkHrxj23ks/46h3EzWOigV4n2ngFnzIvlFYXZy5Qc/qr9qFi39Q2fpo",
+	"fNfHU4QBOxB2L+hmQMonx4YsPBmJEIzQc5R70eH40/f+X1iQtGlrvhG0DM9+/IOX3TS9CEXVO4VovDY4",
+	"IkFbYO8UpbuLsrxoPT6rxV6+R9Wjw8nH47TIA6Wd3Pb0iKpmq9z/gWfMU+HvqWtG3KnT9+4f9KO7WDnY",
+	"6OMSX/K/9365kY/7fk12QTA8YASSBm1//aIPNXEXuNd3x9KE+Ph6uP5LlYn5egxtr3GBz6R9aBk+iKM9",
+	"nh4bZ5+6v8YDtp7wp+u0negGnz5lH4mzx9FDIhIniVvx3FotZpUFM8KUeDipd6AOPG1en+9Sh89y4OF9",
+	"nutNgK/s1HyOSdlmical222S4moO1//TbP9Zsj4CpbmDfdpNczCu0+nEQ8ZUGbv610I2DYAGef9QVOSa",
+	"AdHA1rrs6nLKritr66dkGGK+unTyK0ShnVihDVwFLUir1yhOcmEsZINHzV+EN63GxS6J6bNmgxVfuwR+",
+	"zxqoQkThqts/6JHz7TOe56BZulQGZKvJWqgBisDSJe7hYbLj+3LDOseILL7yJxolfj+eJ3fjsyV4Qz+l",
+	"G/mQ0mFQnhEPXVzVhV5b5ICr7OkUArpLXY/QYCqqEHJr1KVjkDGTK8uUTIM5kVFBUQgD1sm85klOaLWB",
+	"+S5f69jaNyIDqJ5k3UTI/iPiKLEK0rgz365f3XcJv45Yw2kK5cMwK2JslMff5H4LdU+Vy7ZpyeJr+T1s",
+	"W+OonZjA0bikfpP/gewRe/z4cR3yaFg/lhbYitnnQmYerz+s/X05FLt70kIfEc0fKWz9QHmGeHTlBiQ9",
+	"6tWs8HGWrcRsh1ce/y05RmzjiE16arTGmvJM2ZtKSye9sDa29UaUrOVi+sjb9nj/n7qhfeQWPuxLSZ2n",
+	"Ws166pY8X31usfO5xc7+xmN/iyiRt2la6fZBAv3SD9kjm7FDkMvI+zWdny9M3cFyz5vqWIENTf30NTbd",
+	"ThAjsvqXofvKwxTcvGiFwBsHJmlT9PS9+0cd9UPcb3fJLtyA5ln9oN5tyq6s6bzhN2gcQ9Pl0z/fZybV",
+	"4N6PC9u8QWbPybAjtfX07L9CD6K6wg4L6TxjxHwuD+BlKMEcbSfURZsRM6FB0d8qNhclse8+tcPtvkUJ",
+	"zOsGQH4C0ok3T8Y7BB5FtMaRVpooHtr8UPd/HGFK4HfR5kMxSiOY3cZgj5Dax/evu0eOaY8aG4/Bqd7C",
+	"g6G/2a4CLnq8voKZUekd2EaatEzXCZUTG+v6ILgGqN4UQwlTe+Joy1HYRs3nMV6q1dezFmR/C+HxEAqs",
+	"QcKoyrR6NFsKY5VePyzzjAweXldm2eQSmj6Z3m63iv1RGf9XSOJSbVK/QEcyt3TUpLaO27BgM15vPbsg",
+	"ZN1azVcidvSc66+84jrrS8+ntDrHLIf76y1B08psv2zdHoZ8vBrxcwxyD8c743c7q9/Q9wNtMlS8aa4M",
+	"ZMRZLgauYV7hL/1eN+HPT/w128zB+dk020bnVk+ieNj5Bu7VHXR799AkIiFRk4k+pZH4GLFoxITPiIau",
+	"8kyErlK+SpSWjBMQ92/3VfrPpeK22mlqsNW+Fi2cTnwWmu6mxBiWzoXvVEEqwxFTGKbQSwYsY3HqAzK2",
+	"BA0Td1cN47lRdceg7sUmY8d3rfKJ6lLDvcA4YYtZuGSorJFhpuy5982oZdq2hl/Ctnp9RdlDWW7/Vuzx",
+	"5MhmuTt1RNUgcWs2OL4U2Zuweq70AmzLbiYZjrLCNzZ01Q3pwJab7BEt86Ga8CIFpFb0p5u4waPXf5vI",
+	"fZ3laNVH7RWXEDuumniIQP9kZ3HdPJSjBe1ImLJauMygw45D2JagFqEoFtRq+pA81oxcTYotbta4dNyn",
+	"I+1DpuB6QcKPEhTcX9+8jWSUdnv8d/FoSbfDe8mH3JDvSuu6MNKWcMTWu62et9F6odB8sYHBhL9cSOKI",
+	"QrCYzBv7x7KO0zS3aWkYFI/v77WCWTlhCzGfsBLb6urF7Pvvv6dQ/2L27XffTtmPAXgUpG640vSnQ+G+",
+	"25aUmzuDWw7cli1/5iKevvHXLt5fZEzaxi/wiLI2QU9vDbJ5kJuIx1xhZtm/1e+nlztvKONRtNuw45gs",
+	"0HADdChRGDj7t04QzTENaWCLnqyXienKOl39cQJovQ4TI8JnfsZD54B86L3mCILEVco7+lQ6T86TpbXl",
+	"+ekp5kbzpTL2/Puz789OeSmSzdvN/w0A+/sJjeJ4AAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
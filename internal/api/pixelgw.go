@@ -4,6 +4,7 @@ package api
 
 import (
 	"encoding/json"
+	ne "errors"
 	"net/http"
 
 	"github.com/joe714/pixelgw/internal/durable"
@@ -12,9 +13,28 @@ import (
 )
 
 var statusCodes = map[error]int{
-	errors.ChannelExists:      http.StatusConflict,
-	errors.ChannelNotFound:    http.StatusNotFound,
-	errors.AppIndexOutOfRange: http.StatusBadRequest,
+	errors.ChannelExists:          http.StatusConflict,
+	errors.ChannelNotFound:        http.StatusNotFound,
+	errors.ChannelIsDefault:       http.StatusBadRequest,
+	errors.InvalidFallback:        http.StatusBadRequest,
+	errors.InvalidLocation:        http.StatusBadRequest,
+	errors.AppletNotFound:         http.StatusNotFound,
+	errors.AppIndexOutOfRange:     http.StatusBadRequest,
+	errors.InvalidDisplayTime:     http.StatusBadRequest,
+	errors.InvalidRenderTimeout:   http.StatusBadRequest,
+	errors.InvalidSchedule:        http.StatusBadRequest,
+	errors.InvalidRefreshInterval: http.StatusBadRequest,
+	errors.DeviceNotFound:         http.StatusNotFound,
+	errors.DeviceNotConnected:     http.StatusConflict,
+	errors.InvalidCommand:         http.StatusBadRequest,
+	errors.InvalidFormat:          http.StatusBadRequest,
+	errors.InvalidBrightness:      http.StatusBadRequest,
+	errors.DeviceRejected:         http.StatusForbidden,
+	errors.EnrollmentClosed:       http.StatusForbidden,
+	errors.DeviceNotPending:       http.StatusConflict,
+	errors.DeviceBlocked:          http.StatusForbidden,
+	errors.InvalidNotification:    http.StatusBadRequest,
+	errors.InvalidImage:           http.StatusBadRequest,
 }
 
 type Server struct {
@@ -34,8 +54,10 @@ func RenderError(err error) Error {
 }
 
 func StatusCode(err error) int {
-	if val, ok := statusCodes[err]; ok {
-		return val
+	for e, val := range statusCodes {
+		if ne.Is(err, e) {
+			return val
+		}
 	}
 	return http.StatusInternalServerError
 }
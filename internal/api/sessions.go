@@ -2,25 +2,66 @@ package api
 
 import (
 	"context"
+
+	"github.com/joe714/pixelgw/internal/hub"
 )
 
 func (s *Server) GetSessions(ctx context.Context, request GetSessionsRequestObject) (GetSessionsResponseObject, error) {
 	resp := GetSessions200JSONResponse{}
 	sessions := s.hub.GetSessions()
 	for _, s := range sessions {
-		resp = append(resp, SessionSummary{
+		if request.Params.Anonymous != nil && *request.Params.Anonymous != s.Anonymous {
+			continue
+		}
+		ss := SessionSummary{
 			ID:         &s.SessionID,
 			RemoteAddr: &s.RemoteAddr,
-			Channel: &ChannelRef{
-				UUID: &s.ChannelUUID,
-				Name: &s.ChannelName,
+			Dropped:    &s.Dropped,
+			Pending:    &s.Pending,
+			Anonymous:  &s.Anonymous,
+			Protocol:   renderProtocol(&s),
+			Connected:  &s.Connected,
+			Display: &DisplayProfile{
+				Width:      &s.Profile.Width,
+				Height:     &s.Profile.Height,
+				Format:     &s.Profile.Format,
+				MaxFrames:  &s.Profile.MaxFrames,
+				Brightness: &s.Profile.Brightness,
 			},
+		}
+		if !s.Anonymous {
 			// TODO: Add device name here too
-			Device: &DeviceRef{
+			ss.Device = &DeviceRef{
 				UUID: &s.DeviceUUID,
-			},
-		})
+			}
+		}
+		if !s.Pending {
+			ss.Channel = &ChannelRef{
+				UUID: &s.ChannelUUID,
+				Name: &s.ChannelName,
+			}
+		}
+		resp = append(resp, ss)
 	}
 
 	return resp, nil
 }
+
+func renderProtocol(s *hub.SessionInfo) *DeviceProtocol {
+	if s.Info == nil {
+		return nil
+	}
+	p := DeviceProtocol{
+		Version:      &s.Info.Version,
+		Firmware:     &s.Info.Firmware,
+		Width:        &s.Info.Width,
+		Height:       &s.Info.Height,
+		Capabilities: &s.Info.Capabilities,
+		MaxFrames:    &s.Info.MaxFrames,
+	}
+	if !s.AckTime.IsZero() {
+		p.LastAck = &s.AckSeq
+		p.LastAckTime = &s.AckTime
+	}
+	return &p
+}
@@ -0,0 +1,58 @@
+package catalog
+
+import (
+	"log"
+	"sync"
+
+	"tidbyt.dev/pixlet/runtime"
+)
+
+type appletKey struct {
+	id   string
+	hash string
+}
+
+type cachedApplet struct {
+	once   sync.Once
+	applet *runtime.Applet
+	err    error
+}
+
+// Compiled applets, keyed by manifest ID and bundle hash so an updated
+// bundle is never served from a stale entry.
+type appletCache struct {
+	mu      sync.Mutex
+	entries map[appletKey]*cachedApplet
+}
+
+// LoadApplet returns the compiled applet for m, compiling it on first use.
+// Load failures are cached as well, since the bundle won't change until
+// the catalog is reloaded.
+func (c *Catalog) LoadApplet(m *Manifest) (*runtime.Applet, error) {
+	key := appletKey{id: m.ID, hash: m.Hash}
+	c.applets.mu.Lock()
+	entry := c.applets.entries[key]
+	if entry == nil {
+		entry = &cachedApplet{}
+		c.applets.entries[key] = entry
+	}
+	c.applets.mu.Unlock()
+
+	entry.once.Do(func() {
+		log.Printf("Compile applet %v (%.12s)\n", m.ID, m.Hash)
+		entry.applet, entry.err = runtime.NewAppletFromFS(m.ID, m.Bundle)
+	})
+	return entry.applet, entry.err
+}
+
+func (ac *appletCache) prune(manifests map[string]*Manifest) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	for k := range ac.entries {
+		m := manifests[k.id]
+		if m == nil || m.Hash != k.hash {
+			log.Printf("Drop compiled applet %v (%.12s)\n", k.id, k.hash)
+			delete(ac.entries, k)
+		}
+	}
+}
@@ -1,9 +1,14 @@
 package catalog
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"io/fs"
 	"log"
 	"path/filepath"
+	"slices"
+	"sync"
 
 	"tidbyt.dev/pixlet/manifest"
 )
@@ -11,20 +16,50 @@ import (
 type Manifest struct {
 	manifest.Manifest
 	Bundle fs.FS
+	Hash   string // SHA-256 of the bundle contents
 }
 
 type Catalog struct {
-	Manifests map[string]*Manifest
+	root      fs.FS
+	mu        sync.RWMutex
+	manifests map[string]*Manifest
+	applets   appletCache
 }
 
 func NewCatalog(root fs.FS) *Catalog {
+	manifests, err := loadManifests(root)
+	if err != nil {
+		return nil
+	}
+	return &Catalog{
+		root:      root,
+		manifests: manifests,
+		applets:   appletCache{entries: make(map[appletKey]*cachedApplet)},
+	}
+}
+
+// Reload rescans the catalog root and drops compiled applets whose bundle
+// has changed or been removed.
+func (c *Catalog) Reload() error {
+	manifests, err := loadManifests(c.root)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.manifests = manifests
+	c.mu.Unlock()
+	c.applets.prune(manifests)
+	return nil
+}
+
+func loadManifests(root fs.FS) (map[string]*Manifest, error) {
 	matches, err := fs.Glob(root, "*/manifest.yaml")
 	if err != nil {
 		log.Printf("Failed to find manifest files: %v\n", err)
-		return nil
+		return nil, err
 	}
 
-	catalog := &Catalog{make(map[string]*Manifest)}
+	manifests := make(map[string]*Manifest)
 
 	for _, m := range matches {
 		in, err := root.Open(m)
@@ -33,6 +68,7 @@ func NewCatalog(root fs.FS) *Catalog {
 			continue
 		}
 		mn, err := manifest.LoadManifest(in)
+		in.Close()
 		if err != nil {
 			log.Printf("Failed to load manifest %v: %v\n", m, err)
 			continue
@@ -42,17 +78,66 @@ func NewCatalog(root fs.FS) *Catalog {
 			log.Printf("Failed to create bundle handle %v: %v\n", m, err)
 			continue
 		}
+		hash, err := hashBundle(bundle)
+		if err != nil {
+			log.Printf("Failed to hash bundle %v: %v\n", m, err)
+			continue
+		}
 
-		catalog.Manifests[mn.ID] = &Manifest{
+		manifests[mn.ID] = &Manifest{
 			Manifest: *mn,
 			Bundle:   bundle,
+			Hash:     hash,
 		}
 		log.Printf("Loaded app %v from %v", mn.ID, m)
 	}
-	return catalog
+	return manifests, nil
+}
+
+func hashBundle(bundle fs.FS) (string, error) {
+	h := sha256.New()
+	err := fs.WalkDir(bundle, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		f, err := bundle.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		io.WriteString(h, path)
+		h.Write([]byte{0})
+		_, err = io.Copy(h, f)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func (c *Catalog) FindManifest(id string) *Manifest {
-	m, _ := c.Manifests[id]
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	m, _ := c.manifests[id]
 	return m
 }
+
+// List returns all manifests sorted by ID.
+func (c *Catalog) List() []*Manifest {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	res := make([]*Manifest, 0, len(c.manifests))
+	for _, m := range c.manifests {
+		res = append(res, m)
+	}
+	slices.SortFunc(res, func(a, b *Manifest) int {
+		if a.ID < b.ID {
+			return -1
+		} else if a.ID > b.ID {
+			return 1
+		}
+		return 0
+	})
+	return res
+}
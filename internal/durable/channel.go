@@ -4,20 +4,41 @@ import (
 	"context"
 	ne "errors"
 	"log"
+	"time"
 
 	"github.com/canonical/sqlair"
 	"github.com/google/uuid"
 
 	"github.com/joe714/pixelgw/internal/errors"
+	"github.com/joe714/pixelgw/internal/schedule"
 )
 
 var DefaultChannelUUID = uuid.MustParse("76ffcb18-d3c7-40d5-abea-3fe86d02a4ba")
 
+// What a channel shows when none of its applets can render
+const (
+	FallbackNone       = "none"       // Keep showing the last image
+	FallbackDiagnostic = "diagnostic" // Channel name and the reason
+	FallbackApplet     = "applet"     // Run FallbackAppID
+)
+
+// Kinds of channel entry
+const (
+	KindApplet = "applet" // Catalog applet
+	KindImage  = "image"  // Pushed image stored under an installation ID
+)
+
 type ChannelApplet struct {
-	UUID   uuid.UUID `db:"uuid"`
-	Idx    int       `db:"idx"`
-	AppID  string    `db:"app_id"`
-	Config *string   `db:"config"`
+	UUID            uuid.UUID `db:"uuid"`
+	Idx             int       `db:"idx"`
+	Kind            string    `db:"kind"`
+	AppID           string    `db:"app_id"` // Empty for images
+	InstallationID  *string   `db:"installation_id"`
+	Config          *string   `db:"config"`
+	DisplayTime     *int      `db:"display_time"`     // Seconds, nil for the channel default
+	RenderTimeout   *int      `db:"render_timeout"`   // Seconds, nil for the server default
+	Schedule        *string   `db:"schedule"`         // JSON schedule.Schedule, nil to always show
+	RefreshInterval *int      `db:"refresh_interval"` // Seconds to reuse a render, nil to render every time
 }
 
 type ChannelSubscriber struct {
@@ -26,43 +47,57 @@ type ChannelSubscriber struct {
 }
 
 type Channel struct {
-	UUID        uuid.UUID `db:"uuid"`
-	Name        string    `db:"name"`
-	Comment     *string   `db:"comment"`
-	Applets     []ChannelApplet
-	Subscribers []ChannelSubscriber
+	UUID           uuid.UUID `db:"uuid"`
+	Name           string    `db:"name"`
+	Comment        *string   `db:"comment"`
+	DisplayTime    *int      `db:"display_time"`  // Default applet display time in seconds
+	FitAnimation   bool      `db:"fit_animation"` // Stretch the default to whole animation loops
+	Fallback       string    `db:"fallback"`
+	FallbackAppID  *string   `db:"fallback_app_id"`
+	FallbackConfig *string   `db:"fallback_config"`
+	Timezone       *string   `db:"timezone"` // IANA zone for applet schedules, nil for server local time
+	Latitude       *float64  `db:"latitude"` // For sunrise and sunset in applet schedules
+	Longitude      *float64  `db:"longitude"`
+	Anonymous      bool      `db:"anonymous"` // Allow viewers without a device
+	Applets        []ChannelApplet
+	Subscribers    []ChannelSubscriber
 }
 
-func (store *Store) CreateChannel(ctx context.Context, name string, comment *string) (*Channel, error) {
-	ch := Channel{}
+// CreateChannel inserts a new channel with the attributes in ch and
+// assigns it a new UUID.
+func (store *Store) CreateChannel(ctx context.Context, ch *Channel) error {
+	if ch.Fallback == "" {
+		ch.Fallback = FallbackDiagnostic
+	}
+	err := ch.validate()
+	if err != nil {
+		return err
+	}
+	existing := Channel{}
 	stmt := sqlair.MustPrepare("SELECT &Channel.* FROM channels WHERE name = $M.name", Channel{}, sqlair.M{})
-	err := store.DB.Query(ctx, stmt, sqlair.M{"name": name}).Get(&ch)
+	err = store.DB.Query(ctx, stmt, sqlair.M{"name": ch.Name}).Get(&existing)
 	if err == nil {
-		return nil, errors.Wrap(errors.ChannelExists,
+		return errors.Wrap(errors.ChannelExists,
 			"Channel %v already exists with uuid %v",
-			ch.Name,
-			ch.UUID)
+			existing.Name,
+			existing.UUID)
 	} else if !ne.Is(err, sqlair.ErrNoRows) {
-		return nil, err
+		return err
 	}
 
 	uuid, err := uuid.NewV7()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	ch = Channel{
-		UUID:    uuid,
-		Name:    name,
-		Comment: comment,
-	}
+	ch.UUID = uuid
 	stmt = sqlair.MustPrepare("INSERT INTO channels (*) VALUES($Channel.*)", Channel{})
-	err = store.DB.Query(ctx, stmt, &ch).Run()
+	err = store.DB.Query(ctx, stmt, ch).Run()
 	if err != nil {
 		log.Printf("Error creating channel: %v\n", err)
-		return nil, err
+		return err
 	}
-	return &ch, nil
+	return nil
 }
 
 func (store *Store) GetAllChannels(ctx context.Context) ([]Channel, error) {
@@ -85,7 +120,9 @@ func (store *Store) GetChannelByUUID(ctx context.Context, uuid uuid.UUID) (*Chan
 	err := store.View(ctx, func(tx *TX) error {
 		stmt := sqlair.MustPrepare("SELECT &Channel.* FROM channels WHERE uuid = $M.uuid", Channel{}, sqlair.M{})
 		err := tx.Query(stmt, m).Get(&ch)
-		if err != nil {
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.ChannelNotFound
+		} else if err != nil {
 			return err
 		}
 
@@ -126,15 +163,202 @@ func (store *Store) GetChannelByName(ctx context.Context, name string) (*Channel
 		err := tx.Query(stmt, m).Get(&ch)
 		if ne.Is(err, sqlair.ErrNoRows) {
 			return errors.ChannelNotFound
-		} else {
+		}
+		return err
+	})
+	return &ch, err
+}
+
+func (ch *Channel) validate() error {
+	if ch.DisplayTime != nil && *ch.DisplayTime < 0 {
+		return errors.InvalidDisplayTime
+	}
+	switch ch.Fallback {
+	case FallbackNone, FallbackDiagnostic:
+	case FallbackApplet:
+		if ch.FallbackAppID == nil || *ch.FallbackAppID == "" {
+			return errors.Wrap(errors.InvalidFallback, "fallback applet ID required")
+		}
+	default:
+		return errors.Wrap(errors.InvalidFallback, "unknown fallback %q", ch.Fallback)
+	}
+	if ch.Timezone != nil {
+		_, err := time.LoadLocation(*ch.Timezone)
+		if err != nil {
+			return errors.Wrap(errors.InvalidLocation, "unknown timezone %q", *ch.Timezone)
+		}
+	}
+	if (ch.Latitude == nil) != (ch.Longitude == nil) {
+		return errors.Wrap(errors.InvalidLocation, "latitude and longitude must be set together")
+	}
+	if ch.Latitude != nil && (*ch.Latitude < -90 || *ch.Latitude > 90 || *ch.Longitude < -180 || *ch.Longitude > 180) {
+		return errors.Wrap(errors.InvalidLocation, "coordinates out of range")
+	}
+	return nil
+}
+
+// Location returns the channel's time zone and coordinates for
+// evaluating applet schedules.
+func (ch *Channel) Location() schedule.Location {
+	loc := schedule.Location{Latitude: ch.Latitude, Longitude: ch.Longitude}
+	if ch.Timezone != nil {
+		loc.TZ, _ = time.LoadLocation(*ch.Timezone)
+	}
+	return loc
+}
+
+// checkSunSchedule rejects a schedule relative to sunrise or sunset on a
+// channel without coordinates, where it would never be active.
+func checkSunSchedule(ch *Channel, s *string) error {
+	if s == nil || ch.Latitude != nil {
+		return nil
+	}
+	sched, err := schedule.Parse(*s)
+	if err == nil && sched.UsesSun() {
+		return errors.Wrap(errors.InvalidSchedule, "sunrise and sunset need the channel's latitude and longitude")
+	}
+	return nil
+}
+
+func validateSchedule(s *string) error {
+	if s == nil {
+		return nil
+	}
+	_, err := schedule.Parse(*s)
+	if err != nil {
+		return errors.Wrap(errors.InvalidSchedule, "invalid schedule: %v", err)
+	}
+	return nil
+}
+
+// ModifyChannel updates the name and settings of an existing channel.
+func (store *Store) ModifyChannel(ctx context.Context, ch *Channel) error {
+	err := ch.validate()
+	if err != nil {
+		return err
+	}
+	err = store.Update(ctx, func(tx *TX) error {
+		existing := Channel{}
+		stmt := sqlair.MustPrepare(
+			`SELECT &Channel.* FROM channels
+			    WHERE name = $Channel.name AND uuid != $Channel.uuid`,
+			Channel{})
+		err := tx.Query(stmt, ch).Get(&existing)
+		if err == nil {
+			return errors.Wrap(errors.ChannelExists,
+				"Channel %v already exists with uuid %v",
+				existing.Name,
+				existing.UUID)
+		} else if !ne.Is(err, sqlair.ErrNoRows) {
+			return err
+		}
+
+		if ch.Latitude == nil {
+			apps := []ChannelApplet{}
+			stmt = sqlair.MustPrepare(
+				`SELECT &ChannelApplet.* FROM channel_applets WHERE channel_uuid = $Channel.uuid`,
+				ChannelApplet{},
+				Channel{})
+			err = tx.Query(stmt, ch).GetAll(&apps)
+			if err != nil && !ne.Is(err, sqlair.ErrNoRows) {
+				return err
+			}
+			for _, app := range apps {
+				if checkSunSchedule(ch, app.Schedule) != nil {
+					return errors.Wrap(errors.InvalidLocation,
+						"applet %v uses sunrise or sunset and needs the latitude and longitude", app.UUID)
+				}
+			}
+		}
+
+		stmt = sqlair.MustPrepare(
+			`UPDATE channels
+			      SET name = $Channel.name,
+			          comment = $Channel.comment,
+			          display_time = $Channel.display_time,
+			          fit_animation = $Channel.fit_animation,
+			          fallback = $Channel.fallback,
+			          fallback_app_id = $Channel.fallback_app_id,
+			          fallback_config = $Channel.fallback_config,
+			          timezone = $Channel.timezone,
+			          latitude = $Channel.latitude,
+			          longitude = $Channel.longitude,
+			          anonymous = $Channel.anonymous
+			    WHERE uuid = $Channel.uuid`,
+			Channel{})
+		var outcome sqlair.Outcome
+		err = tx.Query(stmt, ch).Get(&outcome)
+		if err != nil {
+			log.Printf("channel modify failed: %v\n", err)
+			return err
+		}
+		if n, err := outcome.Result().RowsAffected(); err == nil && n == 0 {
+			return errors.ChannelNotFound
+		}
+		return nil
+	})
+	return err
+}
+
+// DeleteChannel removes a channel and its applets. Devices subscribed
+// to it are moved back to the default channel, which cannot be deleted.
+func (store *Store) DeleteChannel(ctx context.Context, channelUUID uuid.UUID) error {
+	if channelUUID == DefaultChannelUUID {
+		return errors.ChannelIsDefault
+	}
+	log.Printf("Delete channel %v\n", channelUUID)
+	err := store.Update(ctx, func(tx *TX) error {
+		m := sqlair.M{"uuid": channelUUID, "default_uuid": DefaultChannelUUID}
+		stmt := sqlair.MustPrepare(
+			`SELECT &Channel.* FROM channels WHERE uuid = $M.uuid`,
+			Channel{},
+			sqlair.M{})
+		ch := Channel{}
+		err := tx.Query(stmt, m).Get(&ch)
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.ChannelNotFound
+		} else if err != nil {
 			return err
 		}
+
+		stmts := []string{
+			`UPDATE devices SET channel_uuid = $M.default_uuid WHERE channel_uuid = $M.uuid`,
+			`DELETE FROM channel_images
+			    WHERE uuid IN (SELECT uuid FROM channel_applets WHERE channel_uuid = $M.uuid)`,
+			`DELETE FROM channel_applets WHERE channel_uuid = $M.uuid`,
+			`DELETE FROM channels WHERE uuid = $M.uuid`,
+		}
+		for _, s := range stmts {
+			err = tx.Query(sqlair.MustPrepare(s, sqlair.M{}), m).Run()
+			if err != nil {
+				log.Printf("Delete channel %v failed: %v\n", ch.Name, err)
+				return err
+			}
+		}
 		return nil
 	})
-	return &ch, err
+	return err
 }
 
 func (store *Store) CreateChannelApplet(ctx context.Context, channelUUID uuid.UUID, app *ChannelApplet) error {
+	if app.DisplayTime != nil && *app.DisplayTime < 0 {
+		return errors.InvalidDisplayTime
+	}
+	app.DisplayTime = zeroAsNil(app.DisplayTime)
+	if app.RenderTimeout != nil && *app.RenderTimeout < 0 {
+		return errors.InvalidRenderTimeout
+	}
+	app.RenderTimeout = zeroAsNil(app.RenderTimeout)
+	if app.RefreshInterval != nil && *app.RefreshInterval < 0 {
+		return errors.InvalidRefreshInterval
+	}
+	err := validateSchedule(app.Schedule)
+	if err != nil {
+		return err
+	}
+	if app.Kind == "" {
+		app.Kind = KindApplet
+	}
 	if uuid.Nil == app.UUID {
 		uuid, err := uuid.NewV7()
 		if err != nil {
@@ -142,7 +366,7 @@ func (store *Store) CreateChannelApplet(ctx context.Context, channelUUID uuid.UU
 		}
 		app.UUID = uuid
 	}
-	err := store.Update(ctx, func(tx *TX) error {
+	err = store.Update(ctx, func(tx *TX) error {
 		m := sqlair.M{"channel_uuid": channelUUID}
 		stmt := sqlair.MustPrepare(
 			`SELECT &Channel.* FROM channels WHERE uuid = $M.channel_uuid`,
@@ -153,6 +377,10 @@ func (store *Store) CreateChannelApplet(ctx context.Context, channelUUID uuid.UU
 		if err != nil {
 			return err
 		}
+		err = checkSunSchedule(&ch, app.Schedule)
+		if err != nil {
+			return err
+		}
 
 		count, err := appletCount(tx, channelUUID)
 		if err != nil {
@@ -199,18 +427,22 @@ func (store *Store) DeleteChannelApplet(ctx context.Context, channelUUID uuid.UU
 			sqlair.M{})
 		app := ChannelApplet{}
 		err := tx.Query(stmt, m).Get(&app)
-		if err != nil {
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.AppletNotFound
+		} else if err != nil {
 			log.Printf("Failed to get applet: %v", err)
 			return err
 		}
 
-		stmt = sqlair.MustPrepare(
+		for _, s := range []string{
+			`DELETE FROM channel_images WHERE uuid = $M.uuid`,
 			`DELETE FROM channel_applets WHERE uuid = $M.uuid`,
-			sqlair.M{})
-		err = tx.Query(stmt, m).Run()
-		if err != nil {
-			log.Printf("Delete failed: %v\n", err)
-			return err
+		} {
+			err = tx.Query(sqlair.MustPrepare(s, sqlair.M{}), m).Run()
+			if err != nil {
+				log.Printf("Delete failed: %v\n", err)
+				return err
+			}
 		}
 
 		count, err := appletCount(tx, channelUUID)
@@ -228,7 +460,34 @@ func (store *Store) DeleteChannelApplet(ctx context.Context, channelUUID uuid.UU
 	return err
 }
 
-func (store *Store) ModifyChannelApplet(ctx context.Context, channelUUID uuid.UUID, appletUUID uuid.UUID, idx *int, cfg *string) error {
+// ChannelAppletPatch holds the attributes to change on an applet instance.
+// Nil fields are left unchanged.
+type ChannelAppletPatch struct {
+	Idx             *int
+	Config          *string
+	DisplayTime     *int    // 0 reverts to the channel default
+	RenderTimeout   *int    // 0 reverts to the server default
+	Schedule        *string // Empty string removes the schedule
+	RefreshInterval *int    // 0 renders every time
+}
+
+// ModifyChannelApplet applies patch to an applet instance.
+func (store *Store) ModifyChannelApplet(ctx context.Context, channelUUID uuid.UUID, appletUUID uuid.UUID, patch *ChannelAppletPatch) error {
+	if patch.DisplayTime != nil && *patch.DisplayTime < 0 {
+		return errors.InvalidDisplayTime
+	}
+	if patch.RenderTimeout != nil && *patch.RenderTimeout < 0 {
+		return errors.InvalidRenderTimeout
+	}
+	if patch.RefreshInterval != nil && *patch.RefreshInterval < 0 {
+		return errors.InvalidRefreshInterval
+	}
+	if patch.Schedule != nil && *patch.Schedule != "" {
+		err := validateSchedule(patch.Schedule)
+		if err != nil {
+			return err
+		}
+	}
 
 	err := store.Update(ctx, func(tx *TX) error {
 		app := ChannelApplet{}
@@ -243,19 +502,52 @@ func (store *Store) ModifyChannelApplet(ctx context.Context, channelUUID uuid.UU
 			return err
 		}
 
-		if cfg != nil {
-			app.Config = cfg
+		if patch.Config != nil {
+			app.Config = patch.Config
+		}
+		if patch.DisplayTime != nil {
+			app.DisplayTime = zeroAsNil(patch.DisplayTime)
+		}
+		if patch.RenderTimeout != nil {
+			app.RenderTimeout = zeroAsNil(patch.RenderTimeout)
+		}
+		if patch.RefreshInterval != nil {
+			app.RefreshInterval = zeroAsNil(patch.RefreshInterval)
+		}
+		if patch.Schedule != nil {
+			app.Schedule = patch.Schedule
+			if *patch.Schedule == "" {
+				app.Schedule = nil
+			}
+			ch := Channel{}
 			stmt = sqlair.MustPrepare(
-				`UPDATE channel_applets SET config = $ChannelApplet.config
-				    WHERE uuid = $ChannelApplet.uuid`,
-				ChannelApplet{})
-			err = tx.Query(stmt, app).Run()
+				`SELECT &Channel.* FROM channels WHERE uuid = $M.channel_uuid`,
+				Channel{},
+				sqlair.M{})
+			err = tx.Query(stmt, m).Get(&ch)
+			if err == nil {
+				err = checkSunSchedule(&ch, app.Schedule)
+			}
 			if err != nil {
-				log.Printf("Failed updating applet config: %v\n", err)
 				return err
 			}
 		}
+		stmt = sqlair.MustPrepare(
+			`UPDATE channel_applets
+			      SET config = $ChannelApplet.config,
+			          display_time = $ChannelApplet.display_time,
+			          render_timeout = $ChannelApplet.render_timeout,
+			          schedule = $ChannelApplet.schedule,
+			          refresh_interval = $ChannelApplet.refresh_interval
+			    WHERE uuid = $ChannelApplet.uuid`,
+			ChannelApplet{})
+		err = tx.Query(stmt, app).Run()
+		if err != nil {
+			log.Printf("Failed updating applet %v: %v\n", app.UUID, err)
+			return err
+		}
 
+		idx := patch.Idx
 		if idx != nil && *idx != app.Idx {
 			log.Printf("Change applet %v original idx: %d new idx: %d\n", app.UUID, app.Idx, *idx)
 			count, err := appletCount(tx, channelUUID)
@@ -341,3 +633,10 @@ func reorder(tx *TX, channelUUID uuid.UUID, curIdx int, newIdx int) error {
 	}
 	return nil
 }
+
+func zeroAsNil(v *int) *int {
+	if v == nil || *v == 0 {
+		return nil
+	}
+	return v
+}
@@ -2,26 +2,103 @@ package durable
 
 import (
 	"context"
-	"errors"
+	"crypto/rand"
+	"encoding/json"
+	ne "errors"
+	"fmt"
 	"log"
+	"math/big"
+	"time"
 
 	"github.com/canonical/sqlair"
 	"github.com/google/uuid"
+
+	"github.com/joe714/pixelgw/internal/errors"
+	"github.com/joe714/pixelgw/internal/schedule"
+)
+
+// Enrollment policies for devices the server hasn't seen before
+const (
+	EnrollOpen     = "open"     // Added to the default channel
+	EnrollApproval = "approval" // Held until approved
+	EnrollClosed   = "closed"   // Refused
+)
+
+// Device states
+const (
+	DeviceApproved = "approved"
+	DevicePending  = "pending"
+	DeviceRejected = "rejected"
 )
 
 type Device struct {
-	UUID        uuid.UUID `db:"uuid"`
-	Name        string    `db:"name"`
-	ChannelUUID uuid.UUID `db:"channel_uuid"`
-	ChannelName *string   `db:"channel_name"`
+	UUID        uuid.UUID  `db:"uuid"`
+	Name        string     `db:"name"`
+	ChannelUUID uuid.UUID  `db:"channel_uuid"`
+	ChannelName *string    `db:"channel_name"`
+	Format      *string    `db:"format"`     // Image format, nil for the default
+	Brightness  *int       `db:"brightness"` // Percent, nil for full brightness
+	Dimming     *string    `db:"dimming"`    // JSON []DimmingRule
+	LastIP      *string    `db:"last_ip"`
+	LastTime    *Timestamp `db:"last_time"` // Last connect, disconnect or frame sent
+	State       string     `db:"state"`
+	PairingCode *string    `db:"pairing_code"` // Shown on a pending device
+
+	// Once a device has been issued a token it must present it, even
+	// after it is revoked, until it is issued a new one.
+	TokenHash     *string    `db:"token_hash"`
+	TokenRequired bool       `db:"token_required"`
+	TokenIssued   *Timestamp `db:"token_issued"`
+}
+
+// DimmingRule sets a device's brightness while its schedule is active.
+// The first active rule wins, and the device's brightness applies when
+// none are.
+type DimmingRule struct {
+	Brightness int               `json:"brightness"`
+	Schedule   schedule.Schedule `json:"schedule"`
+}
+
+// ParseDimming decodes and validates a device's dimming rules.
+func ParseDimming(data string) ([]DimmingRule, error) {
+	var rules []DimmingRule
+	err := json.Unmarshal([]byte(data), &rules)
+	if err != nil {
+		return nil, errors.Wrap(errors.InvalidBrightness, "invalid dimming rules: %v", err)
+	}
+	for _, r := range rules {
+		if r.Brightness < 0 || r.Brightness > 100 {
+			return nil, errors.Wrap(errors.InvalidBrightness, "brightness must be between 0 and 100")
+		}
+		_, err = r.Schedule.Compile(schedule.Location{})
+		if err != nil {
+			return nil, errors.Wrap(errors.InvalidSchedule, "invalid schedule: %v", err)
+		}
+	}
+	return rules, nil
+}
+
+func (d *Device) validate() error {
+	if d.Brightness != nil && (*d.Brightness < 0 || *d.Brightness > 100) {
+		return errors.Wrap(errors.InvalidBrightness, "brightness must be between 0 and 100")
+	}
+	if d.Dimming != nil {
+		_, err := ParseDimming(*d.Dimming)
+		return err
+	}
+	return nil
 }
 
 func (store *Store) GetAllDevices(ctx context.Context) ([]Device, error) {
 	resp := []Device{}
 	err := store.View(ctx, func(tx *TX) error {
 		stmt := sqlair.MustPrepare(
-			`SELECT (d.uuid, d.name, d.channel_uuid, c.name)
-			     AS (&Device.uuid, &Device.name, &Device.channel_uuid, &Device.channel_name)
+			`SELECT (d.uuid, d.name, d.channel_uuid, c.name, d.format, d.brightness, d.dimming,
+			         d.last_ip, d.last_time, d.state, d.pairing_code, d.token_required, d.token_issued)
+			     AS (&Device.uuid, &Device.name, &Device.channel_uuid, &Device.channel_name,
+			         &Device.format, &Device.brightness, &Device.dimming,
+			         &Device.last_ip, &Device.last_time, &Device.state, &Device.pairing_code,
+			         &Device.token_required, &Device.token_issued)
 			   FROM devices d
 		       LEFT JOIN channels c ON d.channel_uuid = c.uuid COLLATE NOCASE`,
 			Device{})
@@ -35,15 +112,21 @@ func (store *Store) GetDeviceByUUID(ctx context.Context, uuid uuid.UUID) (*Devic
 	resp := Device{}
 	err := store.View(ctx, func(tx *TX) error {
 		stmt := sqlair.MustPrepare(
-			`SELECT (d.uuid, d.name, d.channel_uuid, c.name)
-			     AS (&Device.uuid, &Device.name, &Device.channel_uuid, &Device.channel_name)
+			`SELECT (d.uuid, d.name, d.channel_uuid, c.name, d.format, d.brightness, d.dimming,
+			         d.last_ip, d.last_time, d.state, d.pairing_code, d.token_required, d.token_issued)
+			     AS (&Device.uuid, &Device.name, &Device.channel_uuid, &Device.channel_name,
+			         &Device.format, &Device.brightness, &Device.dimming,
+			         &Device.last_ip, &Device.last_time, &Device.state, &Device.pairing_code,
+			         &Device.token_required, &Device.token_issued)
 			   FROM devices d
 		       LEFT JOIN channels c ON d.channel_uuid = c.uuid COLLATE NOCASE
 			   WHERE d.uuid = $M.uuid`,
 			Device{},
 			sqlair.M{})
 		err := tx.Query(stmt, sqlair.M{"uuid": uuid}).Get(&resp)
-		if err != nil {
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.DeviceNotFound
+		} else if err != nil {
 			log.Printf("failed to get device: %v\n", err)
 		}
 		return err
@@ -52,11 +135,18 @@ func (store *Store) GetDeviceByUUID(ctx context.Context, uuid uuid.UUID) (*Devic
 }
 
 func (store *Store) ModifyDevice(ctx context.Context, device *Device) error {
-	err := store.Update(ctx, func(tx *TX) error {
+	err := device.validate()
+	if err != nil {
+		return err
+	}
+	err = store.Update(ctx, func(tx *TX) error {
 		stmt := sqlair.MustPrepare(
 			`UPDATE devices
 			      SET name = $Device.name,
-				      channel_uuid = $Device.channel_uuid
+				      channel_uuid = $Device.channel_uuid,
+				      format = $Device.format,
+				      brightness = $Device.brightness,
+				      dimming = $Device.dimming
 				WHERE uuid = $Device.uuid`,
 			Device{})
 		err := tx.Query(stmt, device).Run()
@@ -68,35 +158,150 @@ func (store *Store) ModifyDevice(ctx context.Context, device *Device) error {
 	return err
 }
 
-func (store *Store) LoginDevice(ctx context.Context, uuid uuid.UUID) (*Device, error) {
+// LoginDevice looks up a connecting device, enrolling it according to
+// the enrollment policy if it is new, and returns whether it was enrolled.
+// Rejected devices, deleted ones that are still blocked, and new ones
+// when enrollment is closed get an error.
+func (store *Store) LoginDevice(ctx context.Context, uuid uuid.UUID, enrollment string) (*Device, bool, error) {
 	d := Device{}
+	enrolled := false
 	err := store.Update(ctx, func(tx *TX) error {
 		stmt := sqlair.MustPrepare(
-			`SELECT (uuid, name, channel_uuid)
+			`SELECT (uuid, name, channel_uuid, format, brightness, dimming, state, pairing_code,
+			         token_hash, token_required)
 			     AS (&Device.*)
 			   FROM devices WHERE uuid = $M.uuid`,
 			Device{},
 			sqlair.M{})
 		err := tx.Query(stmt, sqlair.M{"uuid": uuid}).Get(&d)
 		if err == nil {
+			if d.State == DeviceRejected {
+				return errors.DeviceRejected
+			}
 			return nil
 		}
-		if !errors.Is(err, sqlair.ErrNoRows) {
+		if !ne.Is(err, sqlair.ErrNoRows) {
 			return err
 		}
-		d = Device{UUID: uuid, Name: uuid.String(), ChannelUUID: DefaultChannelUUID}
+		err = checkBlocked(tx, uuid)
+		if err != nil {
+			return err
+		}
+		d = Device{UUID: uuid, Name: uuid.String(), ChannelUUID: DefaultChannelUUID, State: DeviceApproved}
+		switch enrollment {
+		case EnrollClosed:
+			return errors.EnrollmentClosed
+		case EnrollApproval:
+			code, err := newPairingCode()
+			if err != nil {
+				return err
+			}
+			d.State = DevicePending
+			d.PairingCode = &code
+		}
 		stmt = sqlair.MustPrepare(
-			`INSERT INTO devices (uuid, name, channel_uuid) VALUES ($Device.*)`,
+			`INSERT INTO devices (uuid, name, channel_uuid, state, pairing_code) VALUES ($Device.*)`,
 			Device{})
 		err = tx.Query(stmt, d).Run()
 		if err != nil {
 			return err
 		}
+		enrolled = true
 		return nil
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	return &d, enrolled, nil
+}
+
+// newPairingCode returns a six digit code for an admin to match against
+// the one a pending device shows.
+func newPairingCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n), nil
+}
+
+// SetDeviceState approves or rejects a device pending approval.
+func (store *Store) SetDeviceState(ctx context.Context, uuid uuid.UUID, state string) error {
+	err := store.Update(ctx, func(tx *TX) error {
+		d := Device{}
+		stmt := sqlair.MustPrepare(
+			`SELECT &Device.state FROM devices WHERE uuid = $M.uuid`,
+			Device{},
+			sqlair.M{})
+		err := tx.Query(stmt, sqlair.M{"uuid": uuid}).Get(&d)
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.DeviceNotFound
+		} else if err != nil {
+			return err
+		}
+		if d.State != DevicePending {
+			return errors.DeviceNotPending
+		}
+		stmt = sqlair.MustPrepare(
+			`UPDATE devices SET state = $M.state, pairing_code = NULL WHERE uuid = $M.uuid`,
+			sqlair.M{})
+		return tx.Query(stmt, sqlair.M{"uuid": uuid, "state": state}).Run()
+	})
+	return err
+}
+
+// DeviceBlock keeps a deleted device from enrolling again.
+type DeviceBlock struct {
+	UUID  uuid.UUID `db:"uuid"`
+	Until Timestamp `db:"until"`
+}
+
+// DeleteDevice forgets a device, its settings and connection history.
+// With block set, it can't enroll again until that much time has passed.
+func (store *Store) DeleteDevice(ctx context.Context, uuid uuid.UUID, block time.Duration) error {
+	log.Printf("Delete device %v\n", uuid)
+	err := store.Update(ctx, func(tx *TX) error {
+		m := sqlair.M{"uuid": uuid}
+		stmt := sqlair.MustPrepare(`DELETE FROM devices WHERE uuid = $M.uuid`, sqlair.M{})
+		var outcome sqlair.Outcome
+		err := tx.Query(stmt, m).Get(&outcome)
+		if err != nil {
+			return err
+		}
+		if n, err := outcome.Result().RowsAffected(); err == nil && n == 0 {
+			return errors.DeviceNotFound
+		}
+		stmt = sqlair.MustPrepare(`DELETE FROM device_connections WHERE device_uuid = $M.uuid`, sqlair.M{})
+		err = tx.Query(stmt, m).Run()
+		if err != nil || block <= 0 {
+			return err
+		}
+		stmt = sqlair.MustPrepare(
+			`INSERT OR REPLACE INTO device_blocks (uuid, until) VALUES ($DeviceBlock.*)`,
+			DeviceBlock{})
+		return tx.Query(stmt, DeviceBlock{UUID: uuid, Until: Timestamp{time.Now().Add(block)}}).Run()
+	})
+	return err
+}
+
+// checkBlocked returns DeviceBlocked if a deleted device is still blocked
+// from enrolling, and clears the block once it has expired.
+func checkBlocked(tx *TX, uuid uuid.UUID) error {
+	b := DeviceBlock{}
+	stmt := sqlair.MustPrepare(
+		`SELECT &DeviceBlock.* FROM device_blocks WHERE uuid = $M.uuid`,
+		DeviceBlock{},
+		sqlair.M{})
+	err := tx.Query(stmt, sqlair.M{"uuid": uuid}).Get(&b)
+	if ne.Is(err, sqlair.ErrNoRows) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if time.Now().Before(b.Until.Time) {
+		return errors.Wrap(errors.DeviceBlocked, "device %v is blocked until %v", uuid, b.Until.Time.Format(time.RFC3339))
 	}
-	return &d, nil
+	stmt = sqlair.MustPrepare(`DELETE FROM device_blocks WHERE uuid = $M.uuid`, sqlair.M{})
+	return tx.Query(stmt, sqlair.M{"uuid": uuid}).Run()
 }
@@ -0,0 +1,138 @@
+package durable
+
+import (
+	"context"
+	ne "errors"
+	"log"
+
+	"github.com/canonical/sqlair"
+	"github.com/google/uuid"
+
+	"github.com/joe714/pixelgw/internal/errors"
+)
+
+// ChannelImage is the image shown by a KindImage channel entry.
+type ChannelImage struct {
+	UUID  uuid.UUID `db:"uuid"`
+	Image []byte    `db:"image"`
+}
+
+// PutChannelImage stores img under an installation ID in a channel. The
+// entry is moved to idx, or when idx is -1 a new installation is added to
+// the end of the rotation and an existing one keeps its place. Returns the
+// entry and whether it was created.
+func (store *Store) PutChannelImage(ctx context.Context, channelUUID uuid.UUID, installationID string, img []byte, idx int) (*ChannelApplet, bool, error) {
+	if idx < -1 {
+		return nil, false, errors.AppIndexOutOfRange
+	}
+	app := ChannelApplet{}
+	created := false
+	err := store.Update(ctx, func(tx *TX) error {
+		m := sqlair.M{"channel_uuid": channelUUID, "installation_id": installationID}
+		stmt := sqlair.MustPrepare(
+			`SELECT &ChannelApplet.* FROM channel_applets
+			    WHERE channel_uuid = $M.channel_uuid
+			      AND installation_id = $M.installation_id`,
+			ChannelApplet{},
+			sqlair.M{})
+		err := tx.Query(stmt, m).Get(&app)
+		if err == nil {
+			stmt = sqlair.MustPrepare(
+				`UPDATE channel_images SET image = $ChannelImage.image WHERE uuid = $ChannelImage.uuid`,
+				ChannelImage{})
+			err = tx.Query(stmt, ChannelImage{UUID: app.UUID, Image: img}).Run()
+			if err != nil || idx < 0 || idx == app.Idx {
+				return err
+			}
+			count, err := appletCount(tx, channelUUID)
+			if err != nil {
+				return err
+			}
+			if idx >= count {
+				return errors.AppIndexOutOfRange
+			}
+			err = reorder(tx, channelUUID, app.Idx, idx)
+			app.Idx = idx
+			return err
+		} else if !ne.Is(err, sqlair.ErrNoRows) {
+			return err
+		}
+
+		stmt = sqlair.MustPrepare(
+			`SELECT &Channel.* FROM channels WHERE uuid = $M.channel_uuid`,
+			Channel{},
+			sqlair.M{})
+		err = tx.Query(stmt, m).Get(&Channel{})
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.ChannelNotFound
+		} else if err != nil {
+			return err
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		count, err := appletCount(tx, channelUUID)
+		if err != nil {
+			return err
+		}
+		if idx == -1 {
+			idx = count
+		}
+		if idx > count {
+			return errors.AppIndexOutOfRange
+		}
+		if idx < count {
+			err = reorder(tx, channelUUID, count, idx)
+			if err != nil {
+				return err
+			}
+		}
+		app = ChannelApplet{
+			UUID:           id,
+			Idx:            idx,
+			Kind:           KindImage,
+			InstallationID: &installationID,
+		}
+		log.Printf("Add installation %v to channel %v\n", installationID, channelUUID)
+		stmt = sqlair.MustPrepare(
+			`INSERT INTO channel_applets (*) VALUES ($M.channel_uuid, $ChannelApplet.*)`,
+			sqlair.M{},
+			ChannelApplet{})
+		err = tx.Query(stmt, m, app).Run()
+		if err != nil {
+			return err
+		}
+		stmt = sqlair.MustPrepare(
+			`INSERT INTO channel_images (*) VALUES ($ChannelImage.*)`,
+			ChannelImage{})
+		err = tx.Query(stmt, ChannelImage{UUID: id, Image: img}).Run()
+		if err != nil {
+			return err
+		}
+		created = true
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &app, created, nil
+}
+
+// GetChannelImage returns the image of a KindImage channel entry.
+func (store *Store) GetChannelImage(ctx context.Context, entryUUID uuid.UUID) ([]byte, error) {
+	img := ChannelImage{}
+	err := store.View(ctx, func(tx *TX) error {
+		stmt := sqlair.MustPrepare(
+			`SELECT &ChannelImage.* FROM channel_images WHERE uuid = $M.uuid`,
+			ChannelImage{},
+			sqlair.M{})
+		err := tx.Query(stmt, sqlair.M{"uuid": entryUUID}).Get(&img)
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return errors.AppletNotFound
+		}
+		return err
+	})
+	return img.Image, err
+}
@@ -0,0 +1,136 @@
+package durable
+
+import (
+	"context"
+	ne "errors"
+	"time"
+
+	"github.com/canonical/sqlair"
+	"github.com/google/uuid"
+)
+
+// Connections kept per device
+const connectionHistory = 100
+
+// DeviceConnection is one websocket session of a device.
+type DeviceConnection struct {
+	UUID         uuid.UUID  `db:"uuid"`
+	DeviceUUID   uuid.UUID  `db:"device_uuid"`
+	SessionID    uint32     `db:"session_id"`
+	RemoteAddr   string     `db:"remote_addr"`
+	Connected    Timestamp  `db:"connected"`
+	Disconnected *Timestamp `db:"disconnected"` // Nil while connected, or if the server stopped first
+	LastFrame    *Timestamp `db:"last_frame"`
+	Frames       uint64     `db:"frames"`
+}
+
+// RecordConnect starts a connection in the device's history, dropping
+// the oldest beyond the limit, and marks the device as seen.
+func (store *Store) RecordConnect(ctx context.Context, conn *DeviceConnection) error {
+	if conn.UUID == uuid.Nil {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return err
+		}
+		conn.UUID = id
+	}
+	err := store.Update(ctx, func(tx *TX) error {
+		stmt := sqlair.MustPrepare(
+			`INSERT INTO device_connections (*) VALUES ($DeviceConnection.*)`,
+			DeviceConnection{})
+		err := tx.Query(stmt, conn).Run()
+		if err != nil {
+			return err
+		}
+		stmt = sqlair.MustPrepare(
+			`DELETE FROM device_connections
+			   WHERE device_uuid = $M.device_uuid
+			     AND uuid NOT IN (SELECT uuid FROM device_connections
+			                        WHERE device_uuid = $M.device_uuid
+			                        ORDER BY connected DESC LIMIT $M.keep)`,
+			sqlair.M{})
+		err = tx.Query(stmt, sqlair.M{"device_uuid": conn.DeviceUUID, "keep": connectionHistory}).Run()
+		if err != nil {
+			return err
+		}
+		return touchDevice(tx, conn.DeviceUUID, &conn.RemoteAddr, conn.Connected)
+	})
+	return err
+}
+
+// RecordFrames updates a connection's frame count and marks the device as
+// seen.
+func (store *Store) RecordFrames(ctx context.Context, connUUID uuid.UUID, deviceUUID uuid.UUID, frames uint64, last time.Time) error {
+	err := store.Update(ctx, func(tx *TX) error {
+		m := sqlair.M{"uuid": connUUID, "frames": frames, "last_frame": Timestamp{last}}
+		stmt := sqlair.MustPrepare(
+			`UPDATE device_connections
+			    SET frames = $M.frames,
+			        last_frame = $M.last_frame
+			  WHERE uuid = $M.uuid`,
+			sqlair.M{})
+		err := tx.Query(stmt, m).Run()
+		if err != nil {
+			return err
+		}
+		return touchDevice(tx, deviceUUID, nil, Timestamp{last})
+	})
+	return err
+}
+
+// RecordDisconnect ends a connection in the device's history.
+func (store *Store) RecordDisconnect(ctx context.Context, connUUID uuid.UUID, deviceUUID uuid.UUID, t time.Time) error {
+	err := store.Update(ctx, func(tx *TX) error {
+		m := sqlair.M{"uuid": connUUID, "disconnected": Timestamp{t}}
+		stmt := sqlair.MustPrepare(
+			`UPDATE device_connections
+			    SET disconnected = $M.disconnected
+			  WHERE uuid = $M.uuid`,
+			sqlair.M{})
+		err := tx.Query(stmt, m).Run()
+		if err != nil {
+			return err
+		}
+		return touchDevice(tx, deviceUUID, nil, Timestamp{t})
+	})
+	return err
+}
+
+// touchDevice sets when the device was last seen, and from where if addr
+// is set.
+func touchDevice(tx *TX, deviceUUID uuid.UUID, addr *string, t Timestamp) error {
+	m := sqlair.M{"uuid": deviceUUID, "last_time": t}
+	stmt := sqlair.MustPrepare(
+		`UPDATE devices SET last_time = $M.last_time WHERE uuid = $M.uuid`,
+		sqlair.M{})
+	if addr != nil {
+		m["last_ip"] = *addr
+		stmt = sqlair.MustPrepare(
+			`UPDATE devices
+			    SET last_time = $M.last_time,
+			        last_ip = $M.last_ip
+			  WHERE uuid = $M.uuid`,
+			sqlair.M{})
+	}
+	return tx.Query(stmt, m).Run()
+}
+
+// GetDeviceConnections returns the device's connection history, newest
+// first.
+func (store *Store) GetDeviceConnections(ctx context.Context, deviceUUID uuid.UUID) ([]DeviceConnection, error) {
+	resp := []DeviceConnection{}
+	err := store.View(ctx, func(tx *TX) error {
+		stmt := sqlair.MustPrepare(
+			`SELECT &DeviceConnection.* FROM device_connections
+			  WHERE device_uuid = $M.device_uuid
+			  ORDER BY connected DESC`,
+			DeviceConnection{},
+			sqlair.M{})
+		err := tx.Query(stmt, sqlair.M{"device_uuid": deviceUUID}).GetAll(&resp)
+		if ne.Is(err, sqlair.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	return resp, err
+}
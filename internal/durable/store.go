@@ -55,12 +55,20 @@ func NewStore() (*Store, error) {
 	if err != nil {
 		if errors.Is(err, sqlair.ErrNoRows) {
 			v, err = store.initSchema()
+			if err != nil {
+				return nil, err
+			}
 		} else {
 			log.Printf("Error validating schema version: %v\n", err)
 			return nil, err
 		}
 	}
 
+	v, err = store.upgradeSchema(v)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Printf("Current database schema: %v\n", v.Version)
 
 	return &store, nil
@@ -145,3 +153,102 @@ func (store *Store) initSchema() (SchemaVersion, error) {
 
 	return SchemaVersion{Version: 1}, err
 }
+
+// Schema upgrades, indexed by the version they start from less one:
+// schemaUpgrades[0] takes a version 1 database to version 2.
+var schemaUpgrades = [][]string{
+	{
+		`ALTER TABLE channels ADD COLUMN display_time INTEGER`,
+		`ALTER TABLE channels ADD COLUMN fit_animation INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE channel_applets ADD COLUMN display_time INTEGER`,
+	},
+	{
+		`ALTER TABLE channel_applets ADD COLUMN render_timeout INTEGER`,
+	},
+	{
+		`ALTER TABLE channels ADD COLUMN fallback TEXT NOT NULL DEFAULT 'diagnostic'`,
+		`ALTER TABLE channels ADD COLUMN fallback_app_id TEXT`,
+		`ALTER TABLE channels ADD COLUMN fallback_config TEXT`,
+	},
+	{
+		`ALTER TABLE channels ADD COLUMN timezone TEXT`,
+		`ALTER TABLE channels ADD COLUMN latitude REAL`,
+		`ALTER TABLE channels ADD COLUMN longitude REAL`,
+		`ALTER TABLE channel_applets ADD COLUMN schedule TEXT`,
+	},
+	{
+		`ALTER TABLE channel_applets ADD COLUMN refresh_interval INTEGER`,
+	},
+	{
+		`ALTER TABLE channel_applets ADD COLUMN kind TEXT NOT NULL DEFAULT 'applet'`,
+		`ALTER TABLE channel_applets ADD COLUMN installation_id TEXT`,
+		`CREATE UNIQUE INDEX idx_channel_installations ON channel_applets (channel_uuid, installation_id)`,
+		`CREATE TABLE channel_images (
+				uuid TEXT PRIMARY KEY COLLATE NOCASE,
+				image BLOB NOT NULL
+			)`,
+	},
+	{
+		`ALTER TABLE devices ADD COLUMN format TEXT`,
+	},
+	{
+		`ALTER TABLE devices ADD COLUMN brightness INTEGER`,
+		`ALTER TABLE devices ADD COLUMN dimming TEXT`,
+	},
+	{
+		`CREATE TABLE device_connections (
+				uuid TEXT PRIMARY KEY COLLATE NOCASE,
+				device_uuid TEXT NOT NULL COLLATE NOCASE,
+				session_id INTEGER NOT NULL,
+				remote_addr TEXT NOT NULL,
+				connected TEXT NOT NULL,
+				disconnected TEXT,
+				last_frame TEXT,
+				frames INTEGER NOT NULL DEFAULT 0
+			)`,
+		`CREATE INDEX idx_device_connections ON device_connections (device_uuid, connected)`,
+	},
+	{
+		`ALTER TABLE devices ADD COLUMN state TEXT NOT NULL DEFAULT 'approved'`,
+		`ALTER TABLE devices ADD COLUMN pairing_code TEXT`,
+	},
+	{
+		`ALTER TABLE devices ADD COLUMN token_hash TEXT`,
+		`ALTER TABLE devices ADD COLUMN token_required INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE devices ADD COLUMN token_issued TEXT`,
+	},
+	{
+		`ALTER TABLE channels ADD COLUMN anonymous INTEGER NOT NULL DEFAULT 0`,
+	},
+	{
+		`CREATE TABLE device_blocks (
+				uuid TEXT PRIMARY KEY COLLATE NOCASE,
+				until TEXT NOT NULL
+			)`,
+	},
+}
+
+func (store *Store) upgradeSchema(v SchemaVersion) (SchemaVersion, error) {
+	for v.Version <= len(schemaUpgrades) {
+		next := v.Version + 1
+		log.Printf("Upgrade database schema %v -> %v\n", v.Version, next)
+		err := store.Update(context.Background(), func(tx *TX) error {
+			for _, s := range schemaUpgrades[v.Version-1] {
+				log.Println(s)
+				stmt := sqlair.MustPrepare(s)
+				err := tx.Query(stmt).Run()
+				if err != nil {
+					log.Printf("Error: %v", err)
+					return err
+				}
+			}
+			stmt := sqlair.MustPrepare("INSERT INTO schema_version VALUES($SchemaVersion.version)", SchemaVersion{})
+			return tx.Query(stmt, SchemaVersion{Version: next}).Run()
+		})
+		if err != nil {
+			return v, err
+		}
+		v.Version = next
+	}
+	return v, nil
+}
@@ -0,0 +1,41 @@
+package durable
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Fixed width, so stored times sort as text
+const timestampFormat = "2006-01-02T15:04:05.000000000Z"
+
+// Timestamp is a time kept in a TEXT column. The sqlite driver only
+// parses times back out of columns declared as DATETIME.
+type Timestamp struct {
+	time.Time
+}
+
+func (ts *Timestamp) Scan(v any) error {
+	var s string
+	switch v := v.(type) {
+	case time.Time:
+		ts.Time = v
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into a timestamp", v)
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return err
+	}
+	ts.Time = t
+	return nil
+}
+
+func (ts Timestamp) Value() (driver.Value, error) {
+	return ts.Time.UTC().Format(timestampFormat), nil
+}
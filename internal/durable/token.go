@@ -0,0 +1,100 @@
+package durable
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/canonical/sqlair"
+	"github.com/google/uuid"
+
+	"github.com/joe714/pixelgw/internal/errors"
+)
+
+// NewDeviceToken returns a random device secret and the hash to store.
+// Tokens are long and random, so a plain hash is enough.
+func NewDeviceToken() (string, string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Authenticate reports whether token lets the device connect. Devices
+// that have never been issued a token connect by UUID alone. LoginDevice
+// loads the token hash; other lookups leave it out.
+func (d *Device) Authenticate(token string) bool {
+	if !d.TokenRequired {
+		return true
+	}
+	if d.TokenHash == nil || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(*d.TokenHash)) == 1
+}
+
+// SetDeviceToken stores the hash of a newly issued token, replacing any
+// earlier one. With first set it only does so if the device has never
+// had a token, and returns false if it has.
+func (store *Store) SetDeviceToken(ctx context.Context, uuid uuid.UUID, hash string, first bool) (bool, error) {
+	var outcome sqlair.Outcome
+	err := store.Update(ctx, func(tx *TX) error {
+		m := sqlair.M{"uuid": uuid, "token_hash": hash, "token_issued": Timestamp{time.Now()}}
+		query := `UPDATE devices
+			         SET token_hash = $M.token_hash,
+			             token_required = 1,
+			             token_issued = $M.token_issued
+			       WHERE uuid = $M.uuid`
+		if first {
+			query += ` AND token_required = 0`
+		}
+		stmt := sqlair.MustPrepare(query, sqlair.M{})
+		return tx.Query(stmt, m).Get(&outcome)
+	})
+	if err != nil {
+		return false, err
+	}
+	n, err := outcome.Result().RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if n == 0 && !first {
+		return false, errors.DeviceNotFound
+	}
+	return n > 0, nil
+}
+
+// RevokeDeviceToken discards the device's token. It can't connect until
+// it is issued a new one.
+func (store *Store) RevokeDeviceToken(ctx context.Context, uuid uuid.UUID) error {
+	var outcome sqlair.Outcome
+	err := store.Update(ctx, func(tx *TX) error {
+		stmt := sqlair.MustPrepare(
+			`UPDATE devices
+			    SET token_hash = NULL,
+			        token_required = 1
+			  WHERE uuid = $M.uuid`,
+			sqlair.M{})
+		return tx.Query(stmt, sqlair.M{"uuid": uuid}).Get(&outcome)
+	})
+	if err != nil {
+		return err
+	}
+	n, err := outcome.Result().RowsAffected()
+	if err == nil && n == 0 {
+		err = errors.DeviceNotFound
+	}
+	return err
+}
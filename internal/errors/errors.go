@@ -58,7 +58,26 @@ func Code(e error) int32 {
 }
 
 var (
-	ChannelExists      = New(1001, "channel exists")
-	ChannelNotFound    = New(1002, "channel not found")
-	AppIndexOutOfRange = New(1011, "index out of range")
+	ChannelExists          = New(1001, "channel exists")
+	ChannelNotFound        = New(1002, "channel not found")
+	ChannelIsDefault       = New(1003, "the default channel cannot be deleted")
+	InvalidFallback        = New(1004, "invalid channel fallback")
+	InvalidLocation        = New(1005, "invalid channel location")
+	AppletNotFound         = New(1010, "applet not found")
+	AppIndexOutOfRange     = New(1011, "index out of range")
+	InvalidDisplayTime     = New(1012, "display time must not be negative")
+	InvalidRenderTimeout   = New(1013, "render timeout must not be negative")
+	InvalidSchedule        = New(1014, "invalid applet schedule")
+	InvalidRefreshInterval = New(1015, "refresh interval must not be negative")
+	DeviceNotFound         = New(1020, "device not found")
+	DeviceNotConnected     = New(1021, "device not connected")
+	InvalidCommand         = New(1022, "invalid device command")
+	InvalidFormat          = New(1023, "invalid image format")
+	InvalidBrightness      = New(1024, "invalid brightness")
+	DeviceRejected         = New(1025, "device rejected")
+	EnrollmentClosed       = New(1026, "enrollment of new devices is closed")
+	DeviceNotPending       = New(1027, "device is not pending approval")
+	DeviceBlocked          = New(1028, "device is blocked from enrolling")
+	InvalidNotification    = New(1030, "invalid notification")
+	InvalidImage           = New(1031, "invalid image")
 )
@@ -0,0 +1,112 @@
+package hub
+
+import (
+	"context"
+	ne "errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/errors"
+)
+
+// Anonymous viewers subscribe straight to a channel, by UUID or by name
+// prefixed with #, without a devices row. They have no settings, token or
+// connection history, and are only let onto channels that allow them.
+
+// Anonymous reports whether the client is a viewer rather than a device.
+// wsHandler refuses devices with the nil UUID, so only viewers have it.
+func (c *Client) Anonymous() bool {
+	return c.UUID == uuid.Nil
+}
+
+// lookupChannel finds the channel an anonymous viewer asked for.
+func (h *Hub) lookupChannel(ctx context.Context, ref string) (*durable.Channel, error) {
+	if name, ok := strings.CutPrefix(ref, "#"); ok {
+		return h.store.GetChannelByName(ctx, name)
+	}
+	channelUUID, err := uuid.Parse(ref)
+	if err != nil {
+		return nil, errors.Wrap(errors.ChannelNotFound, "invalid channel %q", ref)
+	}
+	return h.store.GetChannelByUUID(ctx, channelUUID)
+}
+
+func (h *Hub) anonymousHandler(w http.ResponseWriter, r *http.Request, host string, ref string) {
+	cfg, err := h.lookupChannel(r.Context(), ref)
+	if err != nil {
+		log.Printf("%v: channel %v: %v", host, ref, err)
+		if ne.Is(err, errors.ChannelNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+	if !cfg.Anonymous {
+		log.Printf("%v: channel %v doesn't allow anonymous viewers", host, cfg.Name)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !h.reserveAnonymous() {
+		log.Printf("%v: too many anonymous viewers", host)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("%v: failed to establish websocket: %v", host, err)
+		h.releaseAnonymous()
+		return
+	}
+	client := NewClient(uuid.Nil, conn)
+	client.declare(profileFromQuery(r.URL.Query(), nil))
+	log.Printf("%v viewing %v from %v (%v)", client, cfg.Name, host, client.Profile())
+	_ = h.register(client, cfg.UUID)
+	client.start()
+}
+
+// reserveAnonymous takes one of the anonymous viewer slots, returning
+// false if they are all in use. The slot is given back when the viewer
+// unregisters.
+func (h *Hub) reserveAnonymous() bool {
+	ok := false
+	_ = RunTask(h.tasks, func() error {
+		if h.config.MaxAnonymous > 0 && h.anonymous >= h.config.MaxAnonymous {
+			return nil
+		}
+		h.anonymous++
+		ok = true
+		return nil
+	})
+	return ok
+}
+
+func (h *Hub) releaseAnonymous() {
+	_ = RunTask(h.tasks, func() error {
+		h.anonymous--
+		return nil
+	})
+}
+
+// DisconnectAnonymous closes the anonymous viewers of a channel, after it
+// stops allowing them. Returns the number closed.
+func (h *Hub) DisconnectAnonymous(channelUUID uuid.UUID) int {
+	var clients []*Client
+	_ = RunTask(h.tasks, func() error {
+		for cl, ch := range h.clients {
+			if cl.Anonymous() && ch.UUID == channelUUID {
+				clients = append(clients, cl)
+			}
+		}
+		return nil
+	})
+	// shutdown unregisters through the hub goroutine
+	for _, cl := range clients {
+		cl.shutdown()
+	}
+	return len(clients)
+}
@@ -0,0 +1,166 @@
+package hub
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/schedule"
+	"tidbyt.dev/pixlet/encode"
+)
+
+// Devices that list this capability in their hello are sent brightness
+// messages. Others are dimmed by scaling the pixels of their images.
+const CapBrightness = "brightness"
+
+// How often dimming schedules are checked
+const dimmingInterval = time.Minute
+
+type dimRule struct {
+	level    int
+	schedule *schedule.Compiled
+}
+
+// dimmer picks a device's brightness as its dimming schedule plays out.
+// A nil dimmer is always at full brightness.
+type dimmer struct {
+	level int
+	rules []dimRule
+}
+
+func (d *dimmer) at(t time.Time) int {
+	if d == nil {
+		return 100
+	}
+	for _, r := range d.rules {
+		if r.schedule.Active(t) {
+			return r.level
+		}
+	}
+	return d.level
+}
+
+// newDimmer compiles a device's brightness settings. Sun times are those
+// at the location of the device's channel.
+func newDimmer(d *durable.Device, loc schedule.Location) (*dimmer, error) {
+	if d.Brightness == nil && d.Dimming == nil {
+		return nil, nil
+	}
+	dm := dimmer{level: 100}
+	if d.Brightness != nil {
+		dm.level = *d.Brightness
+	}
+	if d.Dimming != nil {
+		rules, err := durable.ParseDimming(*d.Dimming)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rules {
+			c, err := r.Schedule.Compile(loc)
+			if err != nil {
+				return nil, err
+			}
+			dm.rules = append(dm.rules, dimRule{level: r.Brightness, schedule: c})
+		}
+	}
+	return &dm, nil
+}
+
+// dimmerFor loads what newDimmer needs for d.
+func (h *Hub) dimmerFor(ctx context.Context, d *durable.Device) *dimmer {
+	if d.Brightness == nil && d.Dimming == nil {
+		return nil
+	}
+	var loc schedule.Location
+	ch, err := h.store.GetChannelByUUID(ctx, d.ChannelUUID)
+	if err == nil {
+		loc = ch.Location()
+	}
+	dm, err := newDimmer(d, loc)
+	if err != nil {
+		log.Printf("%v cannot use brightness settings: %v\n", d.UUID, err)
+		return nil
+	}
+	return dm
+}
+
+// dim scales pixel values, for devices that can't dim themselves.
+func dim(level int) encode.ImageFilter {
+	scale := func(v uint32) uint16 { return uint16(v * uint32(level) / 100) }
+	return func(in image.Image) (image.Image, error) {
+		b := in.Bounds()
+		out := image.NewRGBA64(b)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				r, g, bl, a := in.At(x, y).RGBA()
+				out.SetRGBA64(x, y, color.RGBA64{scale(r), scale(g), scale(bl), uint16(a)})
+			}
+		}
+		return out, nil
+	}
+}
+
+// setDimmer replaces the client's brightness settings. Returns true if
+// its images need encoding again.
+func (c *Client) setDimmer(d *dimmer) bool {
+	c.infoMu.Lock()
+	c.dimmer = d
+	c.infoMu.Unlock()
+	return c.updateBrightness(time.Now())
+}
+
+// updateBrightness applies the brightness the client's dimming schedule
+// calls for at t, if it changed. Returns true if its images need encoding
+// again.
+func (c *Client) updateBrightness(t time.Time) bool {
+	c.infoMu.Lock()
+	level := c.dimmer.at(t)
+	if level == c.brightness {
+		c.infoMu.Unlock()
+		return false
+	}
+	c.brightness = level
+	native := slices.Contains(c.info.Capabilities, CapBrightness)
+	old := c.profile.Brightness
+	c.profile.Brightness = level
+	if native {
+		c.profile.Brightness = 100
+	}
+	reencode := old != c.profile.Brightness
+	c.infoMu.Unlock()
+
+	log.Printf("%v brightness %d%%\n", c, level)
+	if native {
+		c.sendControl(&Message{Type: MsgBrightness, Brightness: &level})
+	}
+	return reencode
+}
+
+// updateBrightness follows the dimming schedules of connected devices.
+// Must be called from the hub goroutine.
+func (h *Hub) updateBrightness() {
+	now := time.Now()
+	for cl, ch := range h.clients {
+		if cl.updateBrightness(now) {
+			ch.subscribe(cl)
+		}
+	}
+}
+
+// SetDeviceBrightness applies a device's updated brightness settings, or
+// its move to a channel elsewhere, to its live sessions.
+func (h *Hub) SetDeviceBrightness(d *durable.Device) {
+	dm := h.dimmerFor(context.Background(), d)
+	_ = RunTask(h.tasks, func() error {
+		for cl, ch := range h.clients {
+			if cl.UUID == d.UUID && cl.setDimmer(dm) {
+				ch.subscribe(cl)
+			}
+		}
+		return nil
+	})
+}
@@ -2,55 +2,137 @@ package hub
 
 import (
 	"context"
-	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/joe714/pixelgw/internal/catalog"
-	"tidbyt.dev/pixlet/encode"
-	"tidbyt.dev/pixlet/runtime"
+	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/schedule"
 )
 
 const (
-	// TODO this should be a config option
+	// Display time for channels that don't configure a default
 	renderPeriod = 15 * time.Second
 )
 
 type AppConfig struct {
-	UUID     uuid.UUID
-	Manifest *catalog.Manifest
-	Config   map[string]string `json:"config"`
-	Ttl      time.Duration     `json:"ttl"`
+	UUID         uuid.UUID
+	Manifest     *catalog.Manifest
+	Config       map[string]string  `json:"config"`
+	Ttl          time.Duration      `json:"ttl"`
+	FitAnimation bool               `json:"fit_animation"`
+	Timeout      time.Duration      `json:"timeout"`
+	Schedule     *schedule.Compiled // Nil to always show
+	Refresh      time.Duration      // How long to reuse a render, 0 to defer to the applet
+	Image        []byte             // Shown as is in place of running an applet
+	hash         string             // Applet bundle and config, see renderKey
 }
 
+// renderKey identifies cached output of an applet instance. Changing the
+// instance's config or the applet's bundle invalidates cached renders.
+type renderKey struct {
+	uuid uuid.UUID
+	hash string
+}
+
+func (app *AppConfig) renderKey() renderKey {
+	return renderKey{uuid: app.UUID, hash: app.hash}
+}
+
+func configHash(m *catalog.Manifest, config map[string]string) string {
+	h := sha256.New()
+	h.Write([]byte(m.Hash))
+	// Map keys are marshalled in sorted order
+	cfg, _ := json.Marshal(config)
+	h.Write(cfg)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// displayTime returns how long to show an image rendered by this applet.
+// When FitAnimation is set, or the applet asked to show its full
+// animation, Ttl is rounded up to a whole number of animation loops so
+// the image isn't cut off mid-loop.
+func (app *AppConfig) displayTime(out *output, hints displayHints) time.Duration {
+	ttl := app.Ttl
+	if ttl <= 0 {
+		ttl = renderPeriod
+	}
+	if !app.FitAnimation && !hints.showFullAnimation {
+		return ttl
+	}
+	loop := out.loop()
+	if loop <= 0 {
+		return ttl
+	}
+	return ((ttl + loop - 1) / loop) * loop
+}
+
+// A channel shows one applet per slot, rendering the applet for the next
+// slot in the background while the current one is on screen. A render
+// that isn't ready when its slot begins is skipped, and the channel shows
+// whatever renders next as soon as it is ready.
 type Channel struct {
-	UUID    uuid.UUID
-	Name    string
-	hub     *Hub
-	timer   *time.Timer
-	tasks   chan *task
-	clients map[*Client]bool
-	apps    []AppConfig
-	nextApp int
-	last    *ClientImage
-}
-
-func NewChannel(hub *Hub, uuid uuid.UUID, name string, apps []AppConfig) *Channel {
+	UUID      uuid.UUID
+	name      atomic.Pointer[string] // Renamed by the hub, read from any goroutine
+	hub       *Hub
+	timer     *time.Timer
+	tasks     chan *task
+	results   chan *renderResult
+	quit      chan struct{}
+	clients   map[*Client]bool
+	apps      []AppConfig
+	fallback  Fallback
+	nextApp   int
+	last      *output
+	lastTTL   time.Duration
+	stopped   bool
+	slotEnd   time.Time
+	waiting   bool                        // Slot ended with nothing ready, show the next render immediately
+	rendering *AppConfig                  // Render in flight
+	deadline  time.Time                   // Deadline of the render in flight
+	cancel    func()                      // Cancels the render in flight
+	renderGen uint64                      // Results from an older generation are discarded
+	next      *renderResult               // Ready for the next slot
+	failures  int                         // Consecutive failed or skipped renders
+	lastErr   string                      // Most recent render failure, for the diagnostic screen
+	cache     map[renderKey]*renderResult // Renders that can be reused until they expire
+	renderIdx int                         // Rotation index of the render in flight, -1 for a fallback
+	current   *renderResult               // On screen, unless a notice is showing
+	notices   noticeQueue
+	notice    *Notice       // Showing in place of the rotation
+	resume    *renderResult // Slot interrupted by the notice, with the time it had left
+}
+
+func NewChannel(hub *Hub, id uuid.UUID, name string, apps []AppConfig, fallback Fallback) *Channel {
 	ch := Channel{
-		UUID:    uuid,
-		Name:    name,
-		hub:     hub,
-		timer:   time.NewTimer(time.Nanosecond),
-		tasks:   make(chan *task),
-		clients: make(map[*Client]bool),
-		apps:    apps,
-		nextApp: 0,
-		last:    nil,
+		UUID:     id,
+		hub:      hub,
+		timer:    time.NewTimer(time.Nanosecond),
+		tasks:    make(chan *task),
+		results:  make(chan *renderResult),
+		quit:     make(chan struct{}),
+		clients:  make(map[*Client]bool),
+		cache:    make(map[renderKey]*renderResult),
+		apps:     apps,
+		fallback: fallback,
+		nextApp:  0,
 	}
+	ch.name.Store(&name)
 	return &ch
 }
 
+// Name returns the channel's current name.
+func (c *Channel) Name() string {
+	return *c.name.Load()
+}
+
 func (c *Channel) start() {
 	go c.run()
 }
@@ -58,66 +140,292 @@ func (c *Channel) start() {
 func (c *Channel) run() {
 	defer func() {
 		c.timer.Stop()
+		close(c.quit)
 	}()
 
 	for {
 		select {
 		case <-c.timer.C:
-			buf, ttl := c.renderNext()
-			if buf != nil {
-				// TODO: redo the ttl / priority of channel images vs uploads
-				c.last = &ClientImage{ttl: ttl, data: buf}
-				for client, _ := range c.clients {
-					client.send <- c.last
-				}
-			}
-			c.timer.Reset(ttl)
+			c.advance()
+		case res := <-c.results:
+			c.rendered(res)
 		case task := <-c.tasks:
 			task.run()
+			if c.stopped {
+				log.Printf("%v stopped\n", c.Name())
+				return
+			}
+		}
+	}
+}
+
+// schedule sets the slot timer to fire after d.
+func (c *Channel) schedule(d time.Duration) {
+	if !c.timer.Stop() {
+		select {
+		case <-c.timer.C:
+		default:
 		}
 	}
+	c.timer.Reset(d)
 }
 
-func (c *Channel) renderNext() ([]byte, time.Duration) {
-	lim := len(c.apps)
-	for i := 0; i < lim; i++ {
-		app := c.apps[c.nextApp]
-		c.nextApp = (c.nextApp + 1) % lim
-		log.Printf("%v %v running\n", c.Name, app.Manifest.Name)
-		applet, err := runtime.NewAppletFromFS(app.Manifest.ID, app.Manifest.Bundle)
-		if err != nil {
-			log.Printf("%v %v applet faild to load: %v\n", c.Name, app.Manifest.Name, err)
-			continue
+// advance starts the next slot.
+func (c *Channel) advance() {
+	if c.notice != nil {
+		c.noticeDone()
+		return
+	}
+	if c.next != nil {
+		c.show(c.next)
+		c.next = nil
+		c.startRender()
+		return
+	}
+	if c.rendering != nil && c.deadline.Equal(c.slotEnd) {
+		log.Printf("%v %v missed its slot, skipping\n", c.Name(), c.rendering.Manifest.Name)
+		c.discardRender()
+	}
+	c.waiting = true
+	if !c.startRender() {
+		c.idle()
+	}
+}
+
+// idle is called when there is nothing to render. If the current slot
+// has already ended, the channel's fallback is shown instead.
+func (c *Channel) idle() {
+	log.Printf("%v has nothing to render\n", c.Name())
+	c.failures = 0
+	if !c.waiting {
+		return
+	}
+	switch c.fallback.Mode {
+	case durable.FallbackApplet:
+		c.render(*c.fallback.App, true)
+		return
+	case durable.FallbackDiagnostic:
+		c.render(c.diagnosticApp(), true)
+		return
+	}
+	c.waiting = false
+	c.schedule(renderPeriod)
+}
+
+func (c *Channel) diagnosticApp() AppConfig {
+	msg := c.lastErr
+	if len(c.apps) == 0 {
+		msg = "No applets"
+	} else if msg == "" {
+		msg = "Nothing to show"
+	}
+	return diagnosticApp(c.Name(), msg)
+}
+
+func (c *Channel) show(res *renderResult) {
+	log.Printf("%v %v showing for %v\n", c.Name(), res.app.Manifest.Name, res.ttl)
+	c.current = res
+	c.broadcast(res.out, res.ttl)
+	c.slotEnd = time.Now().Add(res.ttl)
+	c.schedule(res.ttl)
+}
+
+// broadcast sends out to every subscriber, and to new ones as they join.
+func (c *Channel) broadcast(out *output, ttl time.Duration) {
+	c.last = out
+	c.lastTTL = ttl
+	for client := range c.clients {
+		c.deliver(client)
+	}
+}
+
+// deliver sends the last image to client, encoded for its profile.
+func (c *Channel) deliver(client *Client) {
+	if c.last == nil {
+		return
+	}
+	img, err := c.last.encode(client.Profile())
+	if err != nil {
+		log.Printf("%v %v %v\n", c.Name(), client, err)
+		return
+	}
+	client.deliver(&ClientImage{ttl: c.lastTTL, data: img})
+}
+
+// startRender renders the next applet in the rotation in the background,
+// skipping applets that are off schedule, backing off or quarantined.
+// The render is cancelled if it isn't done by the time its slot begins.
+// A cached render that hasn't expired is used without running the applet.
+// Returns false if there was nothing to render.
+func (c *Channel) startRender() bool {
+	if c.rendering != nil {
+		return true
+	}
+	var app AppConfig
+	idx := 0
+	found := false
+	now := time.Now()
+	for i := 0; i < len(c.apps) && !found; i++ {
+		idx = c.nextApp
+		app = c.apps[c.nextApp]
+		c.nextApp = (c.nextApp + 1) % len(c.apps)
+		found = app.Schedule.Active(now) && c.hub.health.ready(app.UUID, now)
+	}
+	if !found {
+		return false
+	}
+	key := app.renderKey()
+	if res, ok := c.cache[key]; ok {
+		if now.Before(res.expires) {
+			// The display settings may have changed since it was cached
+			cached := *res
+			cached.app = app
+			cached.ttl = app.displayTime(res.out, res.hints)
+			c.ready(&cached)
+			return true
 		}
-		roots, err := applet.RunWithConfig(context.Background(), app.Config)
-		if err != nil {
-			log.Printf("%v %v applet failed: %v\n", c.Name, app.Manifest.Name, err)
-			continue
+		delete(c.cache, key)
+	}
+	c.render(app, false)
+	c.renderIdx = idx
+	return true
+}
+
+// render runs app in the background, delivering the result to rendered.
+func (c *Channel) render(app AppConfig, fallback bool) {
+	timeout := app.Timeout
+	if timeout <= 0 {
+		timeout = renderPeriod
+	}
+	deadline := time.Now().Add(timeout)
+	cause := fmt.Errorf("timed out after %v", timeout)
+	if !c.waiting && c.slotEnd.After(time.Now()) && c.slotEnd.Before(deadline) {
+		deadline = c.slotEnd
+		cause = errSlotMissed
+	}
+	ctx, cancel := context.WithDeadlineCause(context.Background(), deadline, cause)
+	c.rendering = &app
+	c.renderIdx = -1
+	c.deadline = deadline
+	c.cancel = cancel
+
+	gen := c.renderGen
+	name := c.Name()
+	cat := c.hub.Catalog
+	profiles := c.profiles()
+	go func() {
+		res := &renderResult{gen: gen, app: app, fallback: fallback}
+		res.out, res.hints, res.err = renderApplet(ctx, cat, name, &app, profiles)
+		if res.err == nil {
+			res.ttl = app.displayTime(res.out, res.hints)
+			// A configured refresh interval overrides the applet's max age
+			refresh := app.Refresh
+			if refresh <= 0 {
+				refresh = res.hints.maxAge
+			}
+			if refresh > 0 {
+				res.expires = time.Now().Add(refresh)
+			}
 		}
-		if roots == nil || len(roots) < 1 {
-			log.Printf("%v %v produced no roots\n", c.Name, app.Manifest.Name)
-			continue
+		select {
+		case c.results <- res:
+		case <-c.quit:
 		}
+	}()
+}
+
+// discardRender drops any prepared or in flight render.
+func (c *Channel) discardRender() {
+	if c.rendering != nil {
+		c.cancel()
+		c.rendering = nil
+	}
+	c.renderGen++
+	c.next = nil
+	c.failures = 0
+}
+
+func (c *Channel) rendered(res *renderResult) {
+	if res.gen != c.renderGen {
+		return
+	}
+	c.cancel()
+	c.rendering = nil
+
+	if res.fallback {
+		c.renderedFallback(res)
+		return
+	}
+
+	if errors.Is(res.err, errNoRoots) {
+		c.retry()
+		return
+	}
+	if errors.Is(res.err, errSlotMissed) {
+		// Ran past the previous applet's display time, which isn't the
+		// applet's fault
+		log.Printf("%v %v %v\n", c.Name(), res.app.Manifest.Name, res.err)
+		c.retry()
+		return
+	}
+	if res.err != nil {
+		log.Printf("%v %v %v\n", c.Name(), res.app.Manifest.Name, res.err)
+		c.lastErr = fmt.Sprintf("%v: %v", res.app.Manifest.Name, res.err)
+		c.hub.health.failure(res.app.UUID, c.Name()+" "+res.app.Manifest.Name, res.err, time.Now())
+		c.retry()
+		return
+	}
 
-		screens := encode.ScreensFromRoots(roots)
-		img, err := screens.EncodeWebP(15000)
-		if err != nil {
-			log.Printf("%v %v encoding failed %v: %v\n", c.Name, app.Manifest.Name, err)
-			continue
+	c.hub.health.success(res.app.UUID, time.Now())
+	c.failures = 0
+	c.lastErr = ""
+	if !res.expires.IsZero() {
+		c.cache[res.app.renderKey()] = res
+	}
+	c.ready(res)
+}
+
+// retry moves on to the next applet after a failed or skipped render.
+func (c *Channel) retry() {
+	c.failures++
+	if c.failures < len(c.apps) && c.startRender() {
+		return
+	}
+	log.Printf("%v ran out of render attempts\n", c.Name())
+	c.idle()
+}
+
+// ready shows res now if the slot has already ended, or holds it for the
+// next slot.
+func (c *Channel) ready(res *renderResult) {
+	if c.waiting {
+		c.waiting = false
+		c.show(res)
+		c.startRender()
+		return
+	}
+	c.next = res
+}
+
+func (c *Channel) renderedFallback(res *renderResult) {
+	if res.err != nil {
+		log.Printf("%v fallback %v %v\n", c.Name(), res.app.Manifest.Name, res.err)
+		if res.app.Manifest != diagnosticManifest {
+			c.render(c.diagnosticApp(), true)
+			return
 		}
-		log.Printf("%v %v success (%v %x)\n", c.Name, app.Manifest.Name, len(img), md5.Sum(img))
-		return img, renderPeriod // TODO make app.Ttl
+		c.waiting = false
+		c.schedule(renderPeriod)
+		return
 	}
-	log.Printf("%v ran out of render attempts\n", c.Name)
-	return nil, renderPeriod
+	c.waiting = false
+	c.show(res)
 }
 
 func (c *Channel) subscribe(client *Client) error {
 	err := RunTask(c.tasks, func() error {
 		c.clients[client] = true
-		if c.last != nil {
-			client.send <- c.last
-		}
+		c.deliver(client)
 		return nil
 	})
 	return err
@@ -131,7 +439,23 @@ func (c *Channel) unsubscribe(client *Client) error {
 	return err
 }
 
-func (c *Channel) setApplets(apps []AppConfig, first uuid.UUID) error {
+// stop shuts down the channel goroutine. The caller must have moved
+// any subscribed clients elsewhere and must not queue further tasks.
+func (c *Channel) stop() error {
+	err := RunTask(c.tasks, func() error {
+		c.stopped = true
+		c.discardRender()
+		clear(c.clients)
+		return nil
+	})
+	return err
+}
+
+func (c *Channel) setName(name string) {
+	c.name.Store(&name)
+}
+
+func (c *Channel) setApplets(apps []AppConfig, fallback Fallback, first uuid.UUID) error {
 	idx := 0
 	if first != uuid.Nil {
 		for i, a := range apps {
@@ -144,17 +468,36 @@ func (c *Channel) setApplets(apps []AppConfig, first uuid.UUID) error {
 
 	err := RunTask(c.tasks, func() error {
 		c.apps = apps
+		c.fallback = fallback
 		c.nextApp = idx
-		if !c.timer.Stop() {
-			log.Printf("%v render now\n", c.Name)
-			<-c.timer.C
+		c.discardRender()
+		c.pruneCache()
+		if c.notice != nil {
+			// Start over with the new applets once the notice is done
+			c.resume = nil
+			return nil
 		}
-		c.timer.Reset(time.Nanosecond)
+		log.Printf("%v render now\n", c.Name())
+		c.schedule(time.Nanosecond)
 		return nil
 	})
 	return err
 }
 
+// pruneCache drops renders of applet instances that were removed or
+// whose config changed.
+func (c *Channel) pruneCache() {
+	keep := make(map[renderKey]bool, len(c.apps))
+	for i := range c.apps {
+		keep[c.apps[i].renderKey()] = true
+	}
+	for k := range c.cache {
+		if !keep[k] {
+			delete(c.cache, k)
+		}
+	}
+}
+
 //func LoadClientConfig(catalog *catalog.Catalog, path string) ([]*AppConfig, error) {
 //	cfgFile, err := os.Open("etc/clients/" + path)
 //	if err != nil {
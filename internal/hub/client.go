@@ -1,9 +1,11 @@
 package hub
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -21,6 +23,7 @@ const (
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
+	Subprotocols:    []string{protocolName},
 }
 
 var lastSessionID atomic.Uint32
@@ -33,24 +36,68 @@ type ClientImage struct {
 // TODO naming here is not quite right.
 // This is really a session or a connection, and ID is really
 // the ClientUUID / connection string, which is either a device ID or
-// a future extension for anonymous subscriptions directly to a channel
+// uuid.Nil for an anonymous viewer subscribed directly to a channel
 type Client struct {
 	SessionID uint32
 	UUID      uuid.UUID
+	Connected time.Time
 	hub       atomic.Pointer[Hub]
 	conn      *websocket.Conn
 	send      chan *ClientImage
+	control   chan []byte  // Control protocol messages
+	lastSent  atomic.Int64 // UnixNano of the last completed write
+	waiting   atomic.Int64 // UnixNano since a frame has waited for the writer, 0 if none
+	dropped   atomic.Uint64
+	stalled   atomic.Bool
+	frames    atomic.Uint64 // Images sent
+
+	// The session enrolled or was approved, and may be issued the
+	// device's first token
+	tokenOffer atomic.Bool
+	// Presented the device's token, or was issued it in this session
+	authenticated atomic.Bool
+
+	// Entry in the device's connection history, set before the client
+	// is registered
+	connUUID uuid.UUID
+	recorded uint64 // Frames last saved, owned by the hub goroutine
+
+	// Set by the hub before the client is subscribed to a channel
+	stallTimeout time.Duration
+
+	noticeMu   sync.Mutex // Also guards send against being closed mid push
+	closed     bool
+	notices    noticeQueue
+	notice     *Notice      // Showing in place of the channel
+	noticeGen  uint64       // Guards against stale notice timers
+	channelImg *ClientImage // Latest image from the channel, shown after a notice
+
+	infoMu  sync.Mutex
+	info    DeviceInfo
+	profile Profile
+	format  string // Chosen when connecting, overrides the hello
+	ackSeq  uint64
+	ackTime time.Time
+
+	dimmer     *dimmer
+	brightness int // Percent, as last applied
 }
 
+// NewClient wraps a websocket connection. The caller starts it once it is
+// registered with the hub, so a connection that drops straight away still
+// unregisters.
 func NewClient(clientUUID uuid.UUID, conn *websocket.Conn) *Client {
 	client := Client{
-		SessionID: lastSessionID.Add(1),
-		UUID:      clientUUID,
-		conn:      conn,
-		send:      make(chan *ClientImage, 1),
+		SessionID:  lastSessionID.Add(1),
+		UUID:       clientUUID,
+		Connected:  time.Now(),
+		conn:       conn,
+		send:       make(chan *ClientImage, 1),
+		control:    make(chan []byte, 8),
+		profile:    DefaultProfile,
+		brightness: 100,
 	}
-	go client.writePump()
-	go client.readPump()
+	client.lastSent.Store(time.Now().UnixNano())
 	return &client
 }
 
@@ -73,7 +120,83 @@ func (c *Client) shutdown() {
 	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 	c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 	c.conn.Close()
+	c.noticeMu.Lock()
+	c.closed = true
 	close(c.send)
+	close(c.control)
+	c.noticeMu.Unlock()
+}
+
+// Profile returns what the device can display.
+func (c *Client) Profile() Profile {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.profile
+}
+
+// declare sets the profile a device declared in its websocket URL. A
+// hello that already arrived takes precedence, except that a format
+// chosen in the URL or the device's settings is kept.
+func (c *Client) declare(p Profile, pinFormat bool) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	if pinFormat {
+		c.format = p.Format
+	}
+	if c.info.Version == 0 {
+		p.Brightness = c.profile.Brightness
+		c.profile = p
+	} else if pinFormat {
+		c.profile.Format = p.Format
+	}
+}
+
+// Dropped returns the number of frames replaced before the client
+// could send them.
+func (c *Client) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// deliver sends an image from the client's channel, unless a notice is
+// showing in its place.
+func (c *Client) deliver(img *ClientImage) {
+	c.noticeMu.Lock()
+	defer c.noticeMu.Unlock()
+	c.channelImg = img
+	if c.notice == nil {
+		c.push(img)
+	}
+}
+
+// push queues img for sending without blocking. A frame still waiting
+// to go out is replaced, so a slow client only ever gets the latest image.
+// A client whose writer hasn't taken a frame for stallTimeout while one was
+// waiting is disconnected. Must be called with noticeMu held.
+func (c *Client) push(img *ClientImage) {
+	if c.closed {
+		return
+	}
+	stallTimeout := c.stallTimeout
+	for {
+		select {
+		case c.send <- img:
+			c.waiting.CompareAndSwap(0, time.Now().UnixNano())
+			return
+		default:
+		}
+		select {
+		case <-c.send:
+			c.dropped.Add(1)
+			waiting := c.waiting.Load()
+			since := time.Since(time.Unix(0, waiting))
+			if stallTimeout > 0 && waiting != 0 && since > stallTimeout && c.stalled.CompareAndSwap(false, true) {
+				log.Printf("%v stalled for %v, disconnecting\n", c, since)
+				// shutdown has to wait on the channel that's calling us
+				go c.shutdown()
+			}
+		default:
+		}
+	}
 }
 
 func (c *Client) readPump() {
@@ -90,7 +213,10 @@ func (c *Client) readPump() {
 	})
 
 	for {
-		_, _, err := c.conn.ReadMessage()
+		mt, data, err := c.conn.ReadMessage()
+		if err == nil && mt == websocket.TextMessage {
+			c.handleMessage(data)
+		}
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("Client %v read error: %v\n", c, err)
@@ -104,6 +230,7 @@ func (c *Client) readPump() {
 
 func (c *Client) writePump() {
 	ping := time.NewTicker(pingPeriod)
+	var seq uint64
 
 	defer func() {
 		log.Printf("%v writePump stopped\n", c)
@@ -118,22 +245,41 @@ func (c *Client) writePump() {
 				// Closed channel means we're already deregistered
 				return
 			}
-			err := c.write(msg.data)
+			c.waiting.Store(0)
+			if _, ok := c.Info(); ok {
+				seq++
+				frame, _ := json.Marshal(Message{Type: MsgFrame, Seq: seq, TTL: msg.ttl.Milliseconds()})
+				if c.writeMessage(websocket.TextMessage, frame, writeWait) != nil {
+					return
+				}
+			}
+			err := c.writeMessage(websocket.BinaryMessage, msg.data, max(writeWait, c.stallTimeout))
 			if err != nil {
-				break
+				return
+			}
+			c.lastSent.Store(time.Now().UnixNano())
+			c.frames.Add(1)
+		case data, ok := <-c.control:
+			if !ok {
+				return
+			}
+			if c.writeMessage(websocket.TextMessage, data, writeWait) != nil {
+				return
 			}
 		case <-ping.C:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
-				break
+				return
 			}
 		}
 	}
 }
 
-func (c *Client) write(data []byte) error {
-	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
-	w, err := c.conn.NextWriter(websocket.BinaryMessage)
+// writeMessage sends a message, giving up after wait. Images may take as
+// long as the stall timeout on a slow link.
+func (c *Client) writeMessage(messageType int, data []byte, wait time.Duration) error {
+	c.conn.SetWriteDeadline(time.Now().Add(wait))
+	w, err := c.conn.NextWriter(messageType)
 	if err == nil {
 		l, err := w.Write(data)
 		if err != nil {
@@ -148,5 +294,8 @@ func (c *Client) write(data []byte) error {
 }
 
 func (c *Client) String() string {
+	if c.Anonymous() {
+		return fmt.Sprintf("[%d anonymous]", c.SessionID)
+	}
 	return fmt.Sprintf("[%d %v]", c.SessionID, c.UUID)
 }
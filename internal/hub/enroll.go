@@ -0,0 +1,99 @@
+package hub
+
+import (
+	"context"
+	ne "errors"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/joe714/pixelgw/internal/errors"
+)
+
+// loginStatus is the HTTP status for a device that failed to log in.
+func loginStatus(err error) int {
+	if ne.Is(err, errors.DeviceRejected) || ne.Is(err, errors.EnrollmentClosed) || ne.Is(err, errors.DeviceBlocked) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// registerPending holds a device awaiting approval on a pairing screen
+// instead of a channel.
+func (h *Hub) registerPending(client *Client, code string) error {
+	claimed := client.hub.CompareAndSwap(nil, h)
+	if !claimed {
+		return ne.New("Client registered to different hub")
+	}
+	client.stallTimeout = h.config.StallTimeout
+	_ = RunTask(h.tasks, func() error {
+		h.pending[client] = code
+		return nil
+	})
+	go h.showPairing(client, code)
+	return nil
+}
+
+// showPairing renders the pairing screen for client.
+func (h *Hub) showPairing(client *Client, code string) {
+	app := diagnosticApp("Pending approval", "Pairing code "+code)
+	ctx, cancel := context.WithTimeout(context.Background(), renderPeriod)
+	defer cancel()
+	out, _, err := renderApplet(ctx, h.Catalog, "pairing", &app, nil)
+	var img []byte
+	if err == nil {
+		img, err = out.encode(client.Profile())
+	}
+	if err != nil {
+		log.Printf("%v pairing screen failed: %v\n", client, err)
+		return
+	}
+	client.deliver(&ClientImage{ttl: renderPeriod, data: img})
+}
+
+// ApproveDevice moves the live sessions of a newly approved device from
+// the pairing screen to its channel.
+func (h *Hub) ApproveDevice(deviceUUID uuid.UUID, channelUUID uuid.UUID) error {
+	err := RunTask(h.tasks, func() error {
+		for cl := range h.pending {
+			if cl.UUID != deviceUUID {
+				continue
+			}
+			ch, err := h.getChannel(channelUUID)
+			if err != nil {
+				return err
+			}
+			log.Printf("%v approved, register %v\n", cl, ch.Name())
+			delete(h.pending, cl)
+			h.subscribe(cl, ch)
+			cl.offerToken(h)
+		}
+		return nil
+	})
+	return err
+}
+
+// DisconnectDevice closes all of a device's live sessions. Returns the
+// number closed.
+func (h *Hub) DisconnectDevice(deviceUUID uuid.UUID) int {
+	var clients []*Client
+	_ = RunTask(h.tasks, func() error {
+		for cl := range h.clients {
+			if cl.UUID == deviceUUID {
+				clients = append(clients, cl)
+			}
+		}
+		for cl := range h.pending {
+			if cl.UUID == deviceUUID {
+				clients = append(clients, cl)
+			}
+		}
+		return nil
+	})
+	// shutdown unregisters through the hub goroutine
+	for _, cl := range clients {
+		cl.shutdown()
+	}
+	return len(clients)
+}
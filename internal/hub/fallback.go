@@ -0,0 +1,35 @@
+package hub
+
+import (
+	"embed"
+
+	"github.com/joe714/pixelgw/internal/catalog"
+	"tidbyt.dev/pixlet/manifest"
+)
+
+//go:embed diagnostic.star
+var diagnosticBundle embed.FS
+
+var diagnosticManifest = &catalog.Manifest{
+	Manifest: manifest.Manifest{
+		ID:   "pixelgw-diagnostic",
+		Name: "Diagnostic",
+	},
+	Bundle: diagnosticBundle,
+	Hash:   "builtin",
+}
+
+// Fallback is what a channel shows when none of its applets can render.
+type Fallback struct {
+	Mode string     // One of the durable.Fallback modes
+	App  *AppConfig // Applet to run for durable.FallbackApplet
+}
+
+// diagnosticApp renders the channel name and why there is nothing to show.
+func diagnosticApp(channel string, message string) AppConfig {
+	return AppConfig{
+		Manifest: diagnosticManifest,
+		Config:   map[string]string{"title": channel, "message": message},
+		Ttl:      renderPeriod,
+	}
+}
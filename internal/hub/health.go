@@ -0,0 +1,112 @@
+package hub
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Retry delay after the first failure, doubled for each further
+	// consecutive failure up to backoffMax.
+	backoffBase = 30 * time.Second
+	backoffMax  = 30 * time.Minute
+)
+
+// AppletHealth is the failure state of an applet instance.
+type AppletHealth struct {
+	Failures    int // Consecutive failed renders
+	LastError   string
+	LastFailure time.Time
+	LastSuccess time.Time
+	Quarantined bool
+	RetryAt     time.Time // Applet is skipped until then
+}
+
+// healthTracker records render results for every applet instance the hub
+// runs. It outlives the channels so state survives an idle channel being
+// stopped, but not a server restart.
+type healthTracker struct {
+	mu              sync.Mutex
+	applets         map[uuid.UUID]*AppletHealth
+	quarantineAfter int
+}
+
+func newHealthTracker(quarantineAfter int) *healthTracker {
+	return &healthTracker{
+		applets:         make(map[uuid.UUID]*AppletHealth),
+		quarantineAfter: quarantineAfter,
+	}
+}
+
+// ready reports whether the applet may be rendered now.
+func (t *healthTracker) ready(id uuid.UUID, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h := t.applets[id]
+	return h == nil || (!h.Quarantined && !now.Before(h.RetryAt))
+}
+
+func (t *healthTracker) success(id uuid.UUID, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h := t.applets[id]
+	if h == nil {
+		h = &AppletHealth{}
+		t.applets[id] = h
+	}
+	h.Failures = 0
+	h.RetryAt = time.Time{}
+	h.LastSuccess = now
+}
+
+func (t *healthTracker) failure(id uuid.UUID, name string, err error, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h := t.applets[id]
+	if h == nil {
+		h = &AppletHealth{}
+		t.applets[id] = h
+	}
+	h.Failures++
+	h.LastError = err.Error()
+	h.LastFailure = now
+
+	if t.quarantineAfter > 0 && h.Failures >= t.quarantineAfter {
+		log.Printf("%v %v quarantined after %d failures\n", name, id, h.Failures)
+		h.Quarantined = true
+		return
+	}
+	backoff := backoffBase << (h.Failures - 1)
+	if backoff > backoffMax || backoff <= 0 {
+		backoff = backoffMax
+	}
+	log.Printf("%v %v failed %d times, retry in %v\n", name, id, h.Failures, backoff)
+	h.RetryAt = now.Add(backoff)
+}
+
+func (t *healthTracker) get(id uuid.UUID) (AppletHealth, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h := t.applets[id]
+	if h == nil {
+		return AppletHealth{}, false
+	}
+	return *h, true
+}
+
+// reset clears the failure count, backoff and quarantine of an applet,
+// keeping its last error and success times.
+func (t *healthTracker) reset(id uuid.UUID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	h := t.applets[id]
+	if h == nil {
+		return
+	}
+	h.Failures = 0
+	h.Quarantined = false
+	h.RetryAt = time.Time{}
+}
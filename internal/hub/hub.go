@@ -8,11 +8,13 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/joe714/pixelgw/internal/catalog"
 	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/schedule"
 )
 
 type SessionInfo struct {
@@ -21,22 +23,61 @@ type SessionInfo struct {
 	RemoteAddr  string
 	ChannelUUID uuid.UUID
 	ChannelName string
+	Dropped     uint64
+	Pending     bool // Awaiting approval, so not on a channel
+	Anonymous   bool // Viewing a channel without a device
+	Connected   time.Time
+	Profile     Profile
+	Info        *DeviceInfo // Nil if the device doesn't speak the control protocol
+	AckSeq      uint64
+	AckTime     time.Time
+}
+
+type Config struct {
+	// How long a channel keeps running after its last client leaves.
+	// Zero keeps channels running forever.
+	IdleTimeout time.Duration
+	// How long a client may take to write an image, or leave a frame
+	// waiting for its writer, before it is disconnected. Zero never
+	// disconnects, beyond the usual write deadline.
+	StallTimeout time.Duration
+	// How long an applet may run before it is abandoned, unless the
+	// applet instance sets its own timeout.
+	RenderTimeout time.Duration
+	// Consecutive failures before an applet is quarantined and no longer
+	// run until cleared. Zero keeps retrying with backoff forever.
+	QuarantineAfter int
+	// What happens to devices the server hasn't seen before, one of the
+	// durable.Enroll policies.
+	Enrollment string
+	// Most anonymous viewers connected at once, across all channels.
+	// Zero has no limit.
+	MaxAnonymous int
 }
 
 type Hub struct {
-	Catalog  *catalog.Catalog
-	store    *durable.Store
-	clients  map[*Client]*Channel
-	channels map[uuid.UUID]*Channel
-	tasks    chan *task
+	Catalog   *catalog.Catalog
+	config    Config
+	store     *durable.Store
+	clients   map[*Client]*Channel
+	pending   map[*Client]string // Devices awaiting approval, with their pairing code
+	anonymous int                // Anonymous viewers connected or connecting
+	channels  map[uuid.UUID]*Channel
+	idle      map[*Channel]*time.Timer
+	health    *healthTracker
+	tasks     chan *task
 }
 
-func NewHub(store *durable.Store) *Hub {
+func NewHub(store *durable.Store, config Config) *Hub {
 	hub := &Hub{
 		Catalog:  catalog.NewCatalog(os.DirFS("apps")),
+		config:   config,
 		store:    store,
 		clients:  make(map[*Client]*Channel),
+		pending:  make(map[*Client]string),
 		channels: make(map[uuid.UUID]*Channel),
+		idle:     make(map[*Channel]*time.Timer),
+		health:   newHealthTracker(config.QuarantineAfter),
 		tasks:    make(chan *task),
 	}
 
@@ -46,45 +87,148 @@ func NewHub(store *durable.Store) *Hub {
 }
 
 func (h *Hub) run() {
+	dimming := time.NewTicker(dimmingInterval)
+	presence := time.NewTicker(presenceInterval)
 	for {
 		select {
 		case task := <-h.tasks:
 			task.run()
+		case <-dimming.C:
+			h.updateBrightness()
+		case <-presence.C:
+			h.recordPresence()
 		}
 	}
 }
 
 func (h *Hub) appletsFromConfig(cfg *durable.Channel) ([]AppConfig, error) {
 	apps := make([]AppConfig, 0, len(cfg.Applets))
+	loc := cfg.Location()
 	for _, app := range cfg.Applets {
-		m := h.Catalog.FindManifest(app.AppID)
-		if m == nil {
-			log.Printf("%v Cannot find Applet with ID %v", cfg.Name, app.AppID)
+		var ac AppConfig
+		var ok bool
+		if app.Kind == durable.KindImage {
+			ac, ok = h.imageConfig(cfg, &app)
+		} else {
+			ac, ok = h.appletConfig(cfg, &app)
+		}
+		if !ok {
 			continue
 		}
-		args := make(map[string]string)
-		if app.Config != nil {
-			err := json.Unmarshal([]byte(*app.Config), &args)
+		ac.UUID = app.UUID
+		ac.Ttl = renderPeriod
+		ac.Timeout = h.config.RenderTimeout
+		if app.RefreshInterval != nil {
+			ac.Refresh = time.Duration(*app.RefreshInterval) * time.Second
+		}
+		if app.RenderTimeout != nil {
+			ac.Timeout = time.Duration(*app.RenderTimeout) * time.Second
+		}
+		if app.DisplayTime != nil {
+			ac.Ttl = time.Duration(*app.DisplayTime) * time.Second
+		} else {
+			if cfg.DisplayTime != nil {
+				ac.Ttl = time.Duration(*cfg.DisplayTime) * time.Second
+			}
+			ac.FitAnimation = cfg.FitAnimation
+		}
+		if app.Schedule != nil {
+			sched, err := schedule.Parse(*app.Schedule)
+			if err == nil {
+				ac.Schedule, err = sched.Compile(loc)
+			}
 			if err != nil {
-				log.Printf(`%v Cannot unmarshal config for applet %v at index %v "%v": %v`,
-					cfg.Name,
-					app.AppID,
-					app.Idx,
-					app.Config,
-					err)
+				log.Printf("%v Cannot parse schedule for applet %v at index %v: %v", cfg.Name, app.AppID, app.Idx, err)
 				continue
 			}
+			if loc.Latitude == nil && sched.UsesSun() {
+				log.Printf("%v Applet %v at index %v uses sunrise or sunset, but the channel has no location; it won't be shown", cfg.Name, app.AppID, app.Idx)
+			}
 		}
-		apps = append(apps, AppConfig{
-			UUID:     app.UUID,
-			Manifest: m,
-			Config:   args,
-			Ttl:      0,
-		})
+		apps = append(apps, ac)
 	}
 	return apps, nil
 }
 
+func (h *Hub) appletConfig(cfg *durable.Channel, app *durable.ChannelApplet) (AppConfig, bool) {
+	m := h.Catalog.FindManifest(app.AppID)
+	if m == nil {
+		log.Printf("%v Cannot find Applet with ID %v", cfg.Name, app.AppID)
+		return AppConfig{}, false
+	}
+	args := make(map[string]string)
+	if app.Config != nil {
+		err := json.Unmarshal([]byte(*app.Config), &args)
+		if err != nil {
+			log.Printf(`%v Cannot unmarshal config for applet %v at index %v "%v": %v`,
+				cfg.Name,
+				app.AppID,
+				app.Idx,
+				app.Config,
+				err)
+			return AppConfig{}, false
+		}
+	}
+	return AppConfig{
+		Manifest: m,
+		Config:   args,
+		hash:     configHash(m, args),
+	}, true
+}
+
+func (h *Hub) imageConfig(cfg *durable.Channel, app *durable.ChannelApplet) (AppConfig, bool) {
+	img, err := h.store.GetChannelImage(context.Background(), app.UUID)
+	if err != nil {
+		log.Printf("%v Cannot load image at index %v: %v", cfg.Name, app.Idx, err)
+		return AppConfig{}, false
+	}
+	name := app.UUID.String()
+	if app.InstallationID != nil {
+		name = *app.InstallationID
+	}
+	return AppConfig{
+		Manifest: imageManifest(name),
+		Image:    img,
+	}, true
+}
+
+func (h *Hub) fallbackFromConfig(cfg *durable.Channel) Fallback {
+	fb := Fallback{Mode: cfg.Fallback}
+	if fb.Mode != durable.FallbackApplet {
+		return fb
+	}
+
+	fb.Mode = durable.FallbackDiagnostic
+	m := h.Catalog.FindManifest(*cfg.FallbackAppID)
+	if m == nil {
+		log.Printf("%v Cannot find fallback Applet with ID %v", cfg.Name, *cfg.FallbackAppID)
+		return fb
+	}
+	args := make(map[string]string)
+	if cfg.FallbackConfig != nil {
+		err := json.Unmarshal([]byte(*cfg.FallbackConfig), &args)
+		if err != nil {
+			log.Printf(`%v Cannot unmarshal config for fallback applet %v "%v": %v`,
+				cfg.Name,
+				m.ID,
+				*cfg.FallbackConfig,
+				err)
+			return fb
+		}
+	}
+	fb.Mode = durable.FallbackApplet
+	fb.App = &AppConfig{
+		Manifest: m,
+		Config:   args,
+		Ttl:      renderPeriod,
+		Timeout:  h.config.RenderTimeout,
+	}
+	if cfg.DisplayTime != nil {
+		fb.App.Ttl = time.Duration(*cfg.DisplayTime) * time.Second
+	}
+	return fb
+}
+
 func (h *Hub) getChannel(channelUUID uuid.UUID) (*Channel, error) {
 	ch := h.channels[channelUUID]
 	if ch != nil {
@@ -96,17 +240,80 @@ func (h *Hub) getChannel(channelUUID uuid.UUID) (*Channel, error) {
 	}
 
 	apps, err := h.appletsFromConfig(cfg)
-	ch = NewChannel(h, cfg.UUID, cfg.Name, apps)
+	ch = NewChannel(h, cfg.UUID, cfg.Name, apps, h.fallbackFromConfig(cfg))
 	h.channels[cfg.UUID] = ch
 	ch.start()
 	return ch, nil
 }
 
+func (h *Hub) clientCount(ch *Channel) int {
+	n := 0
+	for _, cur := range h.clients {
+		if cur == ch {
+			n++
+		}
+	}
+	return n
+}
+
+// subscribe moves client onto ch, leaving its current channel (if any).
+// Must be called from the hub goroutine.
+func (h *Hub) subscribe(client *Client, ch *Channel) {
+	cur := h.clients[client]
+	if cur == ch {
+		return
+	}
+	if cur != nil {
+		cur.unsubscribe(client)
+	}
+	if t := h.idle[ch]; t != nil {
+		t.Stop()
+		delete(h.idle, ch)
+	}
+	ch.subscribe(client)
+	h.clients[client] = ch
+	client.sendControl(&Message{Type: MsgChannel, Channel: &ChannelRef{UUID: ch.UUID, Name: ch.Name()}})
+	if cur != nil {
+		h.checkIdle(cur)
+	}
+}
+
+// checkIdle schedules a channel with no clients to be stopped once the
+// idle timeout expires. It is restarted by getChannel on next use.
+// Must be called from the hub goroutine.
+func (h *Hub) checkIdle(ch *Channel) {
+	if h.config.IdleTimeout <= 0 || h.channels[ch.UUID] != ch || h.clientCount(ch) > 0 {
+		return
+	}
+	if t := h.idle[ch]; t != nil {
+		t.Reset(h.config.IdleTimeout)
+		return
+	}
+	var t *time.Timer
+	t = time.AfterFunc(h.config.IdleTimeout, func() {
+		_ = RunTask(h.tasks, func() error {
+			if h.idle[ch] != t {
+				// Picked up a client while this was queued
+				return nil
+			}
+			delete(h.idle, ch)
+			if h.channels[ch.UUID] != ch || h.clientCount(ch) > 0 {
+				return nil
+			}
+			log.Printf("%v idle, stopping\n", ch.Name())
+			delete(h.channels, ch.UUID)
+			return ch.stop()
+		})
+	})
+	h.idle[ch] = t
+}
+
 func (h *Hub) register(client *Client, channelUUID uuid.UUID) error {
 	claimed := client.hub.CompareAndSwap(nil, h)
 	if !claimed {
 		return errors.New("Client registered to different hub")
 	}
+	client.stallTimeout = h.config.StallTimeout
 
 	err := RunTask(h.tasks, func() error {
 		nxt, err := h.getChannel(channelUUID)
@@ -116,9 +323,8 @@ func (h *Hub) register(client *Client, channelUUID uuid.UUID) error {
 		}
 		log.Printf("%v register %v\n",
 			client,
-			nxt.Name)
-		nxt.subscribe(client)
-		h.clients[client] = nxt
+			nxt.Name())
+		h.subscribe(client, nxt)
 		return nil
 	})
 	return err
@@ -126,14 +332,20 @@ func (h *Hub) register(client *Client, channelUUID uuid.UUID) error {
 
 func (h *Hub) unregister(client *Client) {
 	_ = RunTask(h.tasks, func() error {
+		delete(h.pending, client)
+		if client.Anonymous() {
+			h.anonymous--
+		}
 		ch := h.clients[client]
 		if ch != nil {
-			log.Printf("%v deregister %v\n", client, ch.Name)
+			log.Printf("%v deregister %v\n", client, ch.Name())
 			ch.unsubscribe(client)
 			delete(h.clients, client)
+			h.checkIdle(ch)
 		}
 		return nil
 	})
+	h.recordDisconnect(client)
 }
 
 func (h *Hub) ReloadApplets(channelUUID uuid.UUID, first uuid.UUID) error {
@@ -143,19 +355,114 @@ func (h *Hub) ReloadApplets(channelUUID uuid.UUID, first uuid.UUID) error {
 			// Channel isn't currently running
 			return nil
 		}
+		return h.reloadChannel(ch, first)
+	})
+	return err
+}
 
-		cfg, err := h.store.GetChannelByUUID(context.Background(), channelUUID)
-		if err != nil {
-			return err
+// ReloadCatalog rescans the applet catalog and reloads every running
+// channel so it picks up the new bundles.
+func (h *Hub) ReloadCatalog() error {
+	err := h.Catalog.Reload()
+	if err != nil {
+		return err
+	}
+	err = RunTask(h.tasks, func() error {
+		for _, ch := range h.channels {
+			err := h.reloadChannel(ch, uuid.Nil)
+			if err != nil {
+				log.Printf("Failed to reload channel %v: %v\n", ch.Name(), err)
+			}
 		}
-		apps, err := h.appletsFromConfig(cfg)
+		return nil
+	})
+	return err
+}
 
-		log.Printf("Reload channel %v with %d applets", ch.Name, len(apps))
-		if err != nil {
-			return err
+func (h *Hub) reloadChannel(ch *Channel, first uuid.UUID) error {
+	cfg, err := h.store.GetChannelByUUID(context.Background(), ch.UUID)
+	if err != nil {
+		return err
+	}
+	apps, err := h.appletsFromConfig(cfg)
+
+	log.Printf("Reload channel %v with %d applets", ch.Name(), len(apps))
+	if err != nil {
+		return err
+	}
+	return ch.setApplets(apps, h.fallbackFromConfig(cfg), first)
+}
+
+// AppletHealth returns the failure state of an applet instance, if it
+// has been run since the server started.
+func (h *Hub) AppletHealth(appletUUID uuid.UUID) (AppletHealth, bool) {
+	return h.health.get(appletUUID)
+}
+
+// ClearQuarantine lets a quarantined or backing off applet run again on
+// its next turn.
+func (h *Hub) ClearQuarantine(appletUUID uuid.UUID) {
+	log.Printf("Clear quarantine for applet %v\n", appletUUID)
+	h.health.reset(appletUUID)
+}
+
+// RenameChannel updates the name of a running channel.
+func (h *Hub) RenameChannel(channelUUID uuid.UUID, name string) error {
+	err := RunTask(h.tasks, func() error {
+		ch := h.channels[channelUUID]
+		if ch == nil || ch.Name() == name {
+			return nil
+		}
+		log.Printf("Rename channel %v to %v", ch.Name(), name)
+		ch.setName(name)
+		return nil
+	})
+	return err
+}
+
+// DeleteChannel stops a running channel and moves its live clients to the
+// default channel. Anonymous viewers are disconnected instead.
+func (h *Hub) DeleteChannel(channelUUID uuid.UUID) error {
+	var viewers []*Client
+	err := RunTask(h.tasks, func() error {
+		ch := h.channels[channelUUID]
+		if ch == nil {
+			return nil
 		}
-		return ch.setApplets(apps, first)
+
+		var dflt *Channel
+		for cl, cur := range h.clients {
+			if cur != ch {
+				continue
+			}
+			if cl.Anonymous() {
+				// Dropped here so shutdown doesn't unsubscribe from the
+				// stopped channel. unregister still frees the slot.
+				delete(h.clients, cl)
+				viewers = append(viewers, cl)
+				continue
+			}
+			if dflt == nil {
+				tmp, err := h.getChannel(durable.DefaultChannelUUID)
+				if err != nil {
+					return err
+				}
+				dflt = tmp
+			}
+			log.Printf("%v moved from deleted channel %v\n", cl, ch.Name())
+			h.subscribe(cl, dflt)
+		}
+
+		if t := h.idle[ch]; t != nil {
+			t.Stop()
+			delete(h.idle, ch)
+		}
+		delete(h.channels, channelUUID)
+		return ch.stop()
 	})
+	for _, cl := range viewers {
+		cl.shutdown()
+	}
 	return err
 }
 
@@ -173,40 +480,20 @@ func (h *Hub) SubscribeDevice(deviceUUID uuid.UUID, channelUUID uuid.UUID) error
 				}
 				nxt = tmp
 			}
-			ch.unsubscribe(cl)
-			nxt.subscribe(cl)
-			h.clients[cl] = nxt
+			h.subscribe(cl, nxt)
 		}
 		return nil
 	})
 	return err
 }
 
-//func (h *Hub) uploadHandler(w http.ResponseWriter, r *http.Request) {
-//	host, _, _ := net.SplitHostPort(r.RemoteAddr)
-//	r.ParseMultipartForm(256 * 1024)
-//	file, _, err := r.FormFile("image")
-//	if err != nil {
-//		log.Printf("%v: upload failed: %v", host, err)
-//		return
-//	}
-//
-//	defer file.Close()
-//	deviceUUID := r.FormValue("device")
-//	data, err := ioutil.ReadAll(file)
-//	log.Printf("%v: upload for %v %v", host, deviceUUID, len(data))
-//	if err != nil {
-//		log.Printf("%v upload read failed: %v", host, err)
-//		return
-//	}
-//
-//	msg := &BroadcastMsg{deviceUUID: deviceUUID, ttl: 15 * time.Second, data: data}
-//	h.broadcast <- msg
-//}
-
 func (h *Hub) wsHandler(w http.ResponseWriter, r *http.Request) {
 	host, _, _ := net.SplitHostPort(r.RemoteAddr)
 	q := r.URL.Query()
+	if q.Has("channel") {
+		h.anonymousHandler(w, r, host, q.Get("channel"))
+		return
+	}
 	id := q.Get("device")
 
 	if len(id) == 0 {
@@ -217,24 +504,51 @@ func (h *Hub) wsHandler(w http.ResponseWriter, r *http.Request) {
 
 	deviceUUID, err := uuid.Parse(id)
 	if err != nil {
-		log.Println("%v %v: Device UUID is not valid: %v", id, host, err)
+		log.Printf("%v %v: Device UUID is not valid: %v", id, host, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if deviceUUID == uuid.Nil {
+		// Reserved for anonymous viewers
+		log.Printf("%v %v: Device UUID is nil", id, host)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
-	device, err := h.store.LoginDevice(r.Context(), deviceUUID)
+	device, enrolled, err := h.store.LoginDevice(r.Context(), deviceUUID, h.config.Enrollment)
 	if err != nil {
-		log.Println("%v %v: failed to get device configuration: %v", deviceUUID, host, err)
+		log.Printf("%v %v: failed to get device configuration: %v", deviceUUID, host, err)
+		w.WriteHeader(loginStatus(err))
+		return
+	}
+	token := requestToken(r)
+	if !device.Authenticate(token) {
+		log.Printf("%v %v: missing or invalid device token", deviceUUID, host)
+		w.WriteHeader(http.StatusUnauthorized)
 		return
 	}
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Println("%v %v: failed to establish websocket: %v", deviceUUID, host, err)
+		log.Printf("%v %v: failed to establish websocket: %v", deviceUUID, host, err)
 		return
 	}
 	client := NewClient(deviceUUID, conn)
-	log.Printf("%v established from %v", client, host)
+	client.authenticated.Store(device.TokenRequired && token != "")
+	client.declare(profileFromQuery(q, device.Format))
+	client.setDimmer(h.dimmerFor(r.Context(), device))
+	h.recordConnect(r.Context(), client, host)
+	log.Printf("%v established from %v (%v)", client, host, client.Profile())
+	if device.State == durable.DevicePending {
+		log.Printf("%v pending approval, pairing code %v", client, *device.PairingCode)
+		_ = h.registerPending(client, *device.PairingCode)
+		client.start()
+		return
+	}
+	if enrolled {
+		client.offerToken(h)
+	}
 	_ = h.register(client, device.ChannelUUID)
+	client.start()
 }
 
 func (h *Hub) GetWsHandler() http.HandlerFunc {
@@ -243,18 +557,43 @@ func (h *Hub) GetWsHandler() http.HandlerFunc {
 
 func (h *Hub) GetSessions() []SessionInfo {
 	resp := []SessionInfo{}
+	anonymous := []SessionInfo{}
 	_ = RunTask(h.tasks, func() error {
 		for k, v := range h.clients {
-			addr, _, _ := net.SplitHostPort(k.RemoteAddr().String())
-			resp = append(resp, SessionInfo{
-				SessionID:   k.SessionID,
-				DeviceUUID:  k.UUID,
-				RemoteAddr:  addr,
-				ChannelUUID: v.UUID,
-				ChannelName: v.Name,
-			})
+			si := sessionInfo(k)
+			si.ChannelUUID = v.UUID
+			si.ChannelName = v.Name()
+			if k.Anonymous() {
+				si.Anonymous = true
+				anonymous = append(anonymous, si)
+				continue
+			}
+			resp = append(resp, si)
+		}
+		for k := range h.pending {
+			si := sessionInfo(k)
+			si.Pending = true
+			resp = append(resp, si)
 		}
 		return nil
 	})
-	return resp
+	// Anonymous viewers are listed after the devices
+	return append(resp, anonymous...)
+}
+
+func sessionInfo(k *Client) SessionInfo {
+	addr, _, _ := net.SplitHostPort(k.RemoteAddr().String())
+	si := SessionInfo{
+		SessionID:  k.SessionID,
+		DeviceUUID: k.UUID,
+		RemoteAddr: addr,
+		Dropped:    k.Dropped(),
+		Connected:  k.Connected,
+		Profile:    k.Profile(),
+	}
+	if info, ok := k.Info(); ok {
+		si.Info = &info
+		si.AckSeq, si.AckTime = k.LastAck()
+	}
+	return si
 }
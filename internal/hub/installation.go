@@ -0,0 +1,46 @@
+package hub
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/joe714/pixelgw/internal/catalog"
+	"tidbyt.dev/pixlet/manifest"
+)
+
+// imageManifest stands in for the applet of a pushed image, so it can be
+// logged and shown like any other entry in the rotation.
+func imageManifest(installationID string) *catalog.Manifest {
+	return &catalog.Manifest{
+		Manifest: manifest.Manifest{
+			ID:   "pushed-image",
+			Name: installationID,
+		},
+	}
+}
+
+// UpdateImage replaces the image of a pushed installation on a running
+// channel without disturbing the rotation.
+func (h *Hub) UpdateImage(channelUUID uuid.UUID, entryUUID uuid.UUID, img []byte) error {
+	err := RunTask(h.tasks, func() error {
+		ch := h.channels[channelUUID]
+		if ch == nil {
+			return nil
+		}
+		return ch.setImage(entryUUID, img)
+	})
+	return err
+}
+
+func (c *Channel) setImage(entryUUID uuid.UUID, img []byte) error {
+	err := RunTask(c.tasks, func() error {
+		for i := range c.apps {
+			if c.apps[i].UUID == entryUUID {
+				log.Printf("%v %v updated\n", c.Name(), c.apps[i].Manifest.Name)
+				c.apps[i].Image = img
+			}
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,326 @@
+package hub
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"log"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joe714/pixelgw/internal/catalog"
+	"tidbyt.dev/pixlet/encode"
+)
+
+// Notice is an image that interrupts a channel's rotation or a device's
+// channel, such as a doorbell alert. A higher priority notice interrupts
+// a lower one, which finishes once the higher one is done.
+type Notice struct {
+	Name     string // For logging
+	Priority int
+	Duration time.Duration // How long each showing lasts
+	Repeat   int           // Times to show it
+	img      []byte
+	shown    int
+}
+
+func NewNotice(name string, img []byte, priority int, duration time.Duration, repeat int) *Notice {
+	if duration <= 0 {
+		duration = renderPeriod
+	}
+	if repeat < 1 {
+		repeat = 1
+	}
+	return &Notice{
+		Name:     name,
+		Priority: priority,
+		Duration: duration,
+		Repeat:   repeat,
+		img:      img,
+	}
+}
+
+// noticeQueue holds pending notices, highest priority first and in
+// arrival order within a priority.
+type noticeQueue []*Notice
+
+func (q *noticeQueue) push(n *Notice) {
+	i := sort.Search(len(*q), func(i int) bool { return (*q)[i].Priority < n.Priority })
+	*q = slices.Insert(*q, i, n)
+}
+
+// requeue puts back an interrupted notice ahead of others of its priority.
+func (q *noticeQueue) requeue(n *Notice) {
+	i := sort.Search(len(*q), func(i int) bool { return (*q)[i].Priority <= n.Priority })
+	*q = slices.Insert(*q, i, n)
+}
+
+func (q *noticeQueue) pop() *Notice {
+	if len(*q) == 0 {
+		return nil
+	}
+	n := (*q)[0]
+	*q = (*q)[1:]
+	return n
+}
+
+// NoticeImage prepares an uploaded image for display. WebP images are
+// sent as is, PNG and GIF images are converted to WebP. GIF frames play
+// at Pixlet's default frame rate.
+func NoticeImage(data []byte) ([]byte, error) {
+	if len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP" {
+		return data, nil
+	}
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized image: %w", err)
+	}
+
+	var frames []image.Image
+	switch format {
+	case "png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		frames = append(frames, img)
+	case "gif":
+		g, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		frames = gifFrames(g)
+	default:
+		return nil, fmt.Errorf("unsupported image format %v", format)
+	}
+	return encode.ScreensFromImages(frames...).EncodeWebP(15000)
+}
+
+// gifFrames composites GIF frames, which may only cover part of the
+// image, into full frames.
+func gifFrames(g *gif.GIF) []image.Image {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	canvas := image.NewRGBA(bounds)
+	frames := make([]image.Image, 0, len(g.Image))
+	for i, p := range g.Image {
+		var prev *image.RGBA
+		if g.Disposal[i] == gif.DisposalPrevious {
+			prev = image.NewRGBA(bounds)
+			copy(prev.Pix, canvas.Pix)
+		}
+		draw.Draw(canvas, p.Bounds(), p, p.Bounds().Min, draw.Over)
+		frame := image.NewRGBA(bounds)
+		copy(frame.Pix, canvas.Pix)
+		frames = append(frames, frame)
+
+		switch g.Disposal[i] {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, p.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = prev
+		}
+	}
+	return frames
+}
+
+// RenderNotice runs an applet once to produce a notice image.
+func (h *Hub) RenderNotice(ctx context.Context, m *catalog.Manifest, config map[string]string) ([]byte, error) {
+	app := AppConfig{
+		Manifest: m,
+		Config:   config,
+		Timeout:  h.config.RenderTimeout,
+	}
+	timeout := app.Timeout
+	if timeout <= 0 {
+		timeout = renderPeriod
+	}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("timed out after %v", timeout))
+	defer cancel()
+	out, _, err := renderApplet(ctx, h.Catalog, "notice", &app, nil)
+	if err != nil {
+		return nil, err
+	}
+	return out.encode(DefaultProfile)
+}
+
+// NotifyChannel interrupts a channel's rotation with n. A channel that
+// isn't running has no devices to show it to, so n is dropped.
+func (h *Hub) NotifyChannel(channelUUID uuid.UUID, n *Notice) error {
+	err := RunTask(h.tasks, func() error {
+		ch := h.channels[channelUUID]
+		if ch == nil {
+			log.Printf("Notice %v dropped, channel %v isn't running\n", n.Name, channelUUID)
+			return nil
+		}
+		cp := *n
+		return ch.notify(&cp)
+	})
+	return err
+}
+
+// DeviceConnected reports whether a device has a live session.
+func (h *Hub) DeviceConnected(deviceUUID uuid.UUID) bool {
+	found := false
+	_ = RunTask(h.tasks, func() error {
+		for cl := range h.clients {
+			if cl.UUID == deviceUUID {
+				found = true
+				break
+			}
+		}
+		return nil
+	})
+	return found
+}
+
+// NotifyDevice shows n on each of a device's live sessions in place of its
+// channel. Returns false if the device isn't connected.
+func (h *Hub) NotifyDevice(deviceUUID uuid.UUID, n *Notice) bool {
+	found := false
+	_ = RunTask(h.tasks, func() error {
+		for cl := range h.clients {
+			if cl.UUID != deviceUUID {
+				continue
+			}
+			cp := *n
+			cl.notify(&cp)
+			found = true
+		}
+		return nil
+	})
+	return found
+}
+
+// notify queues n, interrupting the rotation or a lower priority notice.
+func (c *Channel) notify(n *Notice) error {
+	err := RunTask(c.tasks, func() error {
+		if c.notice != nil && n.Priority <= c.notice.Priority {
+			log.Printf("%v notice %v queued\n", c.Name(), n.Name)
+			c.notices.push(n)
+			return nil
+		}
+		if c.notice != nil {
+			log.Printf("%v notice %v interrupted by %v\n", c.Name(), c.notice.Name, n.Name)
+			c.notices.requeue(c.notice)
+		} else {
+			c.pause()
+		}
+		c.showNotice(n)
+		return nil
+	})
+	return err
+}
+
+// pause stops the rotation for a notice, remembering the interrupted slot
+// so it can be finished afterwards. An applet rendering for the next slot
+// is cancelled and rendered again once the rotation resumes.
+func (c *Channel) pause() {
+	c.resume = nil
+	if c.current != nil && !c.waiting {
+		if left := time.Until(c.slotEnd); left > 0 {
+			res := *c.current
+			res.ttl = left
+			c.resume = &res
+		}
+	}
+	if c.rendering != nil {
+		if c.renderIdx >= 0 && c.renderIdx < len(c.apps) {
+			c.nextApp = c.renderIdx
+		}
+		c.cancel()
+		c.rendering = nil
+		c.renderGen++
+	}
+}
+
+func (c *Channel) showNotice(n *Notice) {
+	log.Printf("%v notice %v showing for %v (%d of %d)\n", c.Name(), n.Name, n.Duration, n.shown+1, n.Repeat)
+	c.notice = n
+	n.shown++
+	c.broadcast(fixedOutput(n.img), n.Duration)
+	c.schedule(n.Duration)
+}
+
+// noticeDone shows the next notice, or resumes the rotation where it was
+// interrupted.
+func (c *Channel) noticeDone() {
+	n := c.notice
+	if n.shown < n.Repeat {
+		c.showNotice(n)
+		return
+	}
+	if next := c.notices.pop(); next != nil {
+		c.showNotice(next)
+		return
+	}
+	c.notice = nil
+	res := c.resume
+	c.resume = nil
+	if res == nil {
+		c.advance()
+		return
+	}
+	log.Printf("%v resuming\n", c.Name())
+	c.show(res)
+	if c.next == nil {
+		c.startRender()
+	}
+}
+
+// notify shows n in place of the channel's images, or queues it behind a
+// notice of the same or higher priority.
+func (c *Client) notify(n *Notice) {
+	c.noticeMu.Lock()
+	defer c.noticeMu.Unlock()
+	if c.notice != nil && n.Priority <= c.notice.Priority {
+		c.notices.push(n)
+		return
+	}
+	if c.notice != nil {
+		c.notices.requeue(c.notice)
+	}
+	c.showNotice(n)
+}
+
+// showNotice must be called with noticeMu held.
+func (c *Client) showNotice(n *Notice) {
+	log.Printf("%v notice %v showing for %v (%d of %d)\n", c, n.Name, n.Duration, n.shown+1, n.Repeat)
+	c.notice = n
+	n.shown++
+	c.noticeGen++
+	gen := c.noticeGen
+	if img, err := fixedOutput(n.img).encode(c.Profile()); err == nil {
+		c.push(&ClientImage{ttl: n.Duration, data: img})
+	} else {
+		log.Printf("%v notice %v %v\n", c, n.Name, err)
+	}
+	time.AfterFunc(n.Duration, func() { c.noticeDone(gen) })
+}
+
+func (c *Client) noticeDone(gen uint64) {
+	c.noticeMu.Lock()
+	defer c.noticeMu.Unlock()
+	if gen != c.noticeGen {
+		// Interrupted by a higher priority notice
+		return
+	}
+	n := c.notice
+	if n.shown < n.Repeat {
+		c.showNotice(n)
+		return
+	}
+	if next := c.notices.pop(); next != nil {
+		c.showNotice(next)
+		return
+	}
+	c.notice = nil
+	if c.channelImg != nil {
+		c.push(c.channelImg)
+	}
+}
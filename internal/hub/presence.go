@@ -0,0 +1,79 @@
+package hub
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joe714/pixelgw/internal/durable"
+)
+
+// How often frame counts of live sessions are saved
+const presenceInterval = time.Minute
+
+// recordConnect starts the client's entry in its device's connection
+// history.
+func (h *Hub) recordConnect(ctx context.Context, client *Client, addr string) {
+	conn := durable.DeviceConnection{
+		DeviceUUID: client.UUID,
+		SessionID:  client.SessionID,
+		RemoteAddr: addr,
+		Connected:  durable.Timestamp{Time: client.Connected},
+	}
+	err := h.store.RecordConnect(ctx, &conn)
+	if err != nil {
+		log.Printf("%v cannot record connection: %v\n", client, err)
+		return
+	}
+	client.connUUID = conn.UUID
+}
+
+// recordDisconnect ends the client's entry in its device's connection
+// history.
+func (h *Hub) recordDisconnect(client *Client) {
+	if client.connUUID == uuid.Nil {
+		return
+	}
+	ctx := context.Background()
+	var err error
+	if frames := client.frames.Load(); frames > 0 {
+		err = h.store.RecordFrames(ctx, client.connUUID, client.UUID, frames, time.Unix(0, client.lastSent.Load()))
+	}
+	if err == nil {
+		err = h.store.RecordDisconnect(ctx, client.connUUID, client.UUID, time.Now())
+	}
+	if err != nil {
+		log.Printf("%v cannot record disconnect: %v\n", client, err)
+	}
+}
+
+// recordPresence saves the frame counts of sessions that were sent
+// frames since the last call. Must be called from the hub goroutine.
+func (h *Hub) recordPresence() {
+	type update struct {
+		client *Client
+		frames uint64
+		last   time.Time
+	}
+	var updates []update
+	for cl := range h.clients {
+		frames := cl.frames.Load()
+		if cl.connUUID == uuid.Nil || frames == cl.recorded {
+			continue
+		}
+		cl.recorded = frames
+		updates = append(updates, update{cl, frames, time.Unix(0, cl.lastSent.Load())})
+	}
+	if len(updates) == 0 {
+		return
+	}
+	go func() {
+		for _, u := range updates {
+			err := h.store.RecordFrames(context.Background(), u.client.connUUID, u.client.UUID, u.frames, u.last)
+			if err != nil {
+				log.Printf("%v cannot record frames: %v\n", u.client, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,248 @@
+package hub
+
+import (
+	"fmt"
+	"image"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"tidbyt.dev/pixlet/encode"
+	"tidbyt.dev/pixlet/render"
+)
+
+// Applets always render at the Tidbyt's resolution, larger displays get
+// the image magnified by a whole number of pixels.
+const (
+	baseWidth  = 64
+	baseHeight = 32
+
+	// Longest animation encoded, as pixlet itself limits it
+	maxAnimationMillis = 15000
+)
+
+// Image formats
+const (
+	FormatWebP   = "webp"
+	FormatGIF    = "gif"
+	FormatPNG    = "png"    // First frame only
+	FormatRGB888 = "rgb888" // Raw frames, see encodeRaw
+	FormatRGB565 = "rgb565"
+)
+
+type encoder func(o *output, maxDuration int, filters []encode.ImageFilter) ([]byte, error)
+
+var encoders = map[string]encoder{
+	FormatWebP: func(o *output, maxDuration int, filters []encode.ImageFilter) ([]byte, error) {
+		return o.screens.EncodeWebP(maxDuration, filters...)
+	},
+	FormatGIF: func(o *output, maxDuration int, filters []encode.ImageFilter) ([]byte, error) {
+		return o.screens.EncodeGIF(maxDuration, filters...)
+	},
+	FormatPNG:    encodePNG,
+	FormatRGB888: encodeRaw(3),
+	FormatRGB565: encodeRaw(2),
+}
+
+// ValidFormat reports whether images can be encoded as format.
+func ValidFormat(format string) bool {
+	_, ok := encoders[format]
+	return ok
+}
+
+// Profile is what a device can display. A channel renders each applet
+// once and encodes it for every distinct profile among its subscribers.
+type Profile struct {
+	Width      int
+	Height     int
+	Format     string
+	MaxFrames  int // Longest animation the device can hold, 0 for no limit
+	Brightness int // Percent the pixels are scaled by
+}
+
+var DefaultProfile = Profile{Width: baseWidth, Height: baseHeight, Format: FormatWebP, Brightness: 100}
+
+// NewProfile builds a profile from what a device declared, using the
+// defaults for anything missing. The format is the first one listed that
+// the server can encode.
+func NewProfile(width, height int, formats []string, maxFrames int) Profile {
+	p := DefaultProfile
+	if width >= baseWidth && height >= baseHeight {
+		p.Width, p.Height = width, height
+	}
+	for _, f := range formats {
+		if _, ok := encoders[f]; ok {
+			p.Format = f
+			break
+		}
+	}
+	p.MaxFrames = max(maxFrames, 0)
+	return p
+}
+
+// profileFromQuery reads a profile from the websocket URL, for devices
+// that don't speak the control protocol. A format in the URL wins over
+// the device's stored setting. Returns whether a format was chosen.
+func profileFromQuery(q url.Values, stored *string) (Profile, bool) {
+	width, _ := strconv.Atoi(q.Get("width"))
+	height, _ := strconv.Atoi(q.Get("height"))
+	maxFrames, _ := strconv.Atoi(q.Get("max_frames"))
+	formats := q["format"]
+	if len(formats) == 0 && stored != nil {
+		formats = []string{*stored}
+	}
+	p := NewProfile(width, height, formats, maxFrames)
+	return p, slices.ContainsFunc(formats, ValidFormat)
+}
+
+// scale is how many display pixels each rendered pixel covers.
+func (p Profile) scale() int {
+	return max(min(p.Width/baseWidth, p.Height/baseHeight), 1)
+}
+
+func (p Profile) String() string {
+	s := fmt.Sprintf("%dx%d %v", p.Width, p.Height, p.Format)
+	if p.MaxFrames > 0 {
+		s += fmt.Sprintf(" max %d frames", p.MaxFrames)
+	}
+	if p.Brightness < 100 {
+		s += fmt.Sprintf(" dimmed to %d%%", p.Brightness)
+	}
+	return s
+}
+
+// asIs reports whether a WebP image at the base resolution can be sent
+// to the device unchanged.
+func (p Profile) asIs() bool {
+	return p.Format == FormatWebP && p.scale() == 1 && p.Brightness >= 100 && p.MaxFrames == 0
+}
+
+// magnify scales an image up by a whole number of pixels.
+func magnify(scale int) encode.ImageFilter {
+	return func(in image.Image) (image.Image, error) {
+		b := in.Bounds()
+		out := image.NewRGBA(image.Rect(0, 0, b.Dx()*scale, b.Dy()*scale))
+		for y := 0; y < out.Rect.Dy(); y++ {
+			for x := 0; x < out.Rect.Dx(); x++ {
+				out.Set(x, y, in.At(b.Min.X+x/scale, b.Min.Y+y/scale))
+			}
+		}
+		return out, nil
+	}
+}
+
+// output is a rendered applet, encoded on demand for each profile. Pushed
+// images are sent as is to devices that take them unchanged, and decoded
+// to be dimmed, magnified or converted for the others. Only the goroutine
+// holding it may call encode.
+type output struct {
+	roots   []render.Root
+	screens *encode.Screens
+	painted []image.Image
+	delay   int // Milliseconds per frame
+	fixed   []byte
+	images  map[Profile][]byte
+}
+
+func newOutput(roots []render.Root) *output {
+	o := &output{images: make(map[Profile][]byte)}
+	o.setRoots(roots)
+	return o
+}
+
+func (o *output) setRoots(roots []render.Root) {
+	delay := int(roots[0].Delay)
+	if delay <= 0 {
+		delay = encode.DefaultScreenDelayMillis
+	}
+	o.roots = roots
+	o.screens = encode.ScreensFromRoots(roots)
+	o.delay = delay
+}
+
+func fixedOutput(img []byte) *output {
+	return &output{fixed: img, images: make(map[Profile][]byte)}
+}
+
+// decode turns a pushed image into frames the way an applet showing it
+// would, so it can be encoded for any profile.
+func (o *output) decode() error {
+	if o.roots != nil {
+		return nil
+	}
+	img := &render.Image{Src: string(o.fixed)}
+	err := img.Init()
+	if err != nil {
+		return fmt.Errorf("decoding pushed image failed: %w", err)
+	}
+	o.setRoots([]render.Root{{Child: img, Delay: int32(img.Delay)}})
+	return nil
+}
+
+func (o *output) encode(p Profile) ([]byte, error) {
+	if o.fixed != nil && p.asIs() {
+		return o.fixed, nil
+	}
+	if img, ok := o.images[p]; ok {
+		return img, nil
+	}
+	if o.fixed != nil {
+		err := o.decode()
+		if err != nil {
+			return nil, err
+		}
+	}
+	enc, ok := encoders[p.Format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format %v", p.Format)
+	}
+	maxDuration := maxAnimationMillis
+	if p.MaxFrames > 0 {
+		maxDuration = min(maxDuration, p.MaxFrames*o.delay)
+	}
+	var filters []encode.ImageFilter
+	if p.Brightness < 100 {
+		filters = append(filters, dim(p.Brightness))
+	}
+	if s := p.scale(); s > 1 {
+		filters = append(filters, magnify(s))
+	}
+	img, err := enc(o, maxDuration, filters)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %v failed: %w", p, err)
+	}
+	o.images[p] = img
+	return img, nil
+}
+
+// frames paints the rendered frames, for the formats pixlet can't encode.
+func (o *output) frames() []image.Image {
+	if o.painted == nil {
+		for _, r := range o.roots {
+			o.painted = append(o.painted, r.Paint(true)...)
+		}
+	}
+	return o.painted
+}
+
+// loop returns the length of one loop of the animation as encoded for
+// the default profile, 0 if it isn't animated.
+func (o *output) loop() time.Duration {
+	img, err := o.encode(DefaultProfile)
+	if err != nil {
+		return 0
+	}
+	return webpDuration(img)
+}
+
+// profiles returns the distinct profiles among the channel's subscribers.
+func (c *Channel) profiles() []Profile {
+	var ps []Profile
+	for client := range c.clients {
+		if p := client.Profile(); !slices.Contains(ps, p) {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
@@ -0,0 +1,233 @@
+package hub
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Devices may speak a JSON control protocol on websocket text frames.
+// Binary frames are always images, so firmware that never sends a hello
+// keeps working unchanged. After a hello the server also announces each
+// image with a frame message, which the device acknowledges once shown.
+const ProtocolVersion = 1
+
+// Message types
+const (
+	// Device to server
+	MsgHello  = "hello"
+	MsgAck    = "ack"
+	MsgButton = "button"
+
+	// Server to device
+	MsgWelcome    = "welcome"
+	MsgFrame      = "frame"
+	MsgChannel    = "channel"
+	MsgBrightness = "brightness"
+	MsgReboot     = "reboot"
+	MsgToken      = "token"
+)
+
+// Message is the envelope of every control message. Only the fields for
+// its type are set.
+type Message struct {
+	Type    string `json:"type"`
+	Version int    `json:"version,omitempty"`
+
+	// hello
+	Firmware     string   `json:"firmware,omitempty"`
+	Width        int      `json:"width,omitempty"`
+	Height       int      `json:"height,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"` // Image formats among them, in order of preference
+	MaxFrames    int      `json:"max_frames,omitempty"`
+
+	// frame, ack
+	Seq uint64 `json:"seq,omitempty"`
+	TTL int64  `json:"ttl_ms,omitempty"`
+
+	// button
+	Button string `json:"button,omitempty"`
+	Action string `json:"action,omitempty"`
+
+	// welcome, channel
+	Session uint32      `json:"session,omitempty"`
+	Channel *ChannelRef `json:"channel,omitempty"`
+
+	// brightness
+	Brightness *int `json:"brightness,omitempty"`
+
+	// token
+	Token string `json:"token,omitempty"`
+}
+
+type ChannelRef struct {
+	UUID uuid.UUID `json:"uuid"`
+	Name string    `json:"name"`
+}
+
+// DeviceInfo is what a device reported in its hello.
+type DeviceInfo struct {
+	Version      int
+	Firmware     string
+	Width        int
+	Height       int
+	Capabilities []string
+	MaxFrames    int
+}
+
+// Info returns what the device reported in its hello, and false if it
+// doesn't speak the control protocol.
+func (c *Client) Info() (DeviceInfo, bool) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.info, c.info.Version > 0
+}
+
+// LastAck returns the sequence number and time of the last frame the
+// device acknowledged.
+func (c *Client) LastAck() (uint64, time.Time) {
+	c.infoMu.Lock()
+	defer c.infoMu.Unlock()
+	return c.ackSeq, c.ackTime
+}
+
+// sendControl queues a control message without blocking. Messages to a
+// device that hasn't said hello are dropped.
+func (c *Client) sendControl(msg *Message) bool {
+	if _, ok := c.Info(); !ok && msg.Type != MsgWelcome {
+		return false
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("%v cannot marshal %v: %v\n", c, msg.Type, err)
+		return false
+	}
+	c.noticeMu.Lock()
+	defer c.noticeMu.Unlock()
+	if c.closed {
+		return false
+	}
+	select {
+	case c.control <- data:
+		return true
+	default:
+		log.Printf("%v control queue full, dropping %v\n", c, msg.Type)
+		return false
+	}
+}
+
+// handleMessage processes a text frame from the device.
+func (c *Client) handleMessage(data []byte) {
+	var msg Message
+	err := json.Unmarshal(data, &msg)
+	if err != nil {
+		log.Printf("%v bad control message: %v\n", c, err)
+		return
+	}
+	switch msg.Type {
+	case MsgHello:
+		c.hello(&msg)
+	case MsgAck:
+		c.infoMu.Lock()
+		c.ackSeq = msg.Seq
+		c.ackTime = time.Now()
+		c.infoMu.Unlock()
+	case MsgButton:
+		log.Printf("%v button %v %v\n", c, msg.Button, msg.Action)
+	default:
+		log.Printf("%v unknown control message %q\n", c, msg.Type)
+	}
+}
+
+func (c *Client) hello(msg *Message) {
+	version := min(msg.Version, ProtocolVersion)
+	if version < 1 {
+		log.Printf("%v hello with unsupported version %v\n", c, msg.Version)
+		return
+	}
+	log.Printf("%v hello v%d firmware %q %dx%d %v\n", c, msg.Version, msg.Firmware, msg.Width, msg.Height, msg.Capabilities)
+	profile := NewProfile(msg.Width, msg.Height, msg.Capabilities, msg.MaxFrames)
+	c.infoMu.Lock()
+	if c.format != "" {
+		profile.Format = c.format
+	}
+	profile.Brightness = c.profile.Brightness
+	c.info = DeviceInfo{
+		Version:      version,
+		Firmware:     msg.Firmware,
+		Width:        msg.Width,
+		Height:       msg.Height,
+		Capabilities: msg.Capabilities,
+		MaxFrames:    msg.MaxFrames,
+	}
+	changed := profile != c.profile
+	c.profile = profile
+	c.brightness = -1 // Whether the device dims itself may have changed
+	c.infoMu.Unlock()
+
+	welcome := Message{Type: MsgWelcome, Version: version, Session: c.SessionID}
+	h := c.hub.Load()
+	if h != nil {
+		welcome.Channel = h.channelRef(c)
+	}
+	c.sendControl(&welcome)
+	if c.updateBrightness(time.Now()) {
+		changed = true
+	}
+	if changed && h != nil {
+		log.Printf("%v profile %v\n", c, profile)
+		h.redeliver(c)
+	}
+	if h != nil {
+		c.takeTokenOffer(h)
+	}
+}
+
+// redeliver sends the client's channel image again, after its profile
+// changed.
+func (h *Hub) redeliver(client *Client) {
+	_ = RunTask(h.tasks, func() error {
+		if ch := h.clients[client]; ch != nil {
+			return ch.subscribe(client)
+		}
+		if code, ok := h.pending[client]; ok {
+			go h.showPairing(client, code)
+		}
+		return nil
+	})
+}
+
+// channelRef returns the channel client is subscribed to.
+func (h *Hub) channelRef(client *Client) *ChannelRef {
+	var ref *ChannelRef
+	_ = RunTask(h.tasks, func() error {
+		if ch := h.clients[client]; ch != nil {
+			ref = &ChannelRef{UUID: ch.UUID, Name: ch.Name()}
+		}
+		return nil
+	})
+	return ref
+}
+
+// SendCommand sends a control message to each of a device's live sessions
+// that speak the protocol. Returns the number of sessions it was sent to.
+func (h *Hub) SendCommand(deviceUUID uuid.UUID, msg *Message) int {
+	var clients []*Client
+	_ = RunTask(h.tasks, func() error {
+		for cl := range h.clients {
+			if cl.UUID == deviceUUID {
+				clients = append(clients, cl)
+			}
+		}
+		return nil
+	})
+	sent := 0
+	for _, cl := range clients {
+		if cl.sendControl(msg) {
+			sent++
+		}
+	}
+	return sent
+}
@@ -0,0 +1,92 @@
+package hub
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+
+	"tidbyt.dev/pixlet/encode"
+)
+
+// Raw frame sequences are for displays that can't decode WebP or GIF.
+// Integers are little endian. The header is
+//
+//	magic    "PGWR"
+//	width    uint16
+//	height   uint16
+//	frames   uint16
+//	bpp      uint8, 3 for RGB888 or 2 for RGB565
+//	reserved uint8
+//
+// followed by each frame: its delay in milliseconds as a uint16, then
+// width*height pixels row by row. RGB565 pixels are a uint16 each.
+const rawMagic = "PGWR"
+
+func encodeRaw(bpp int) encoder {
+	return func(o *output, maxDuration int, filters []encode.ImageFilter) ([]byte, error) {
+		frames, err := filtered(o.frames(), filters)
+		if err != nil {
+			return nil, err
+		}
+		if len(frames) == 0 {
+			return nil, fmt.Errorf("no frames")
+		}
+		n := min(len(frames), max(maxDuration/o.delay, 1))
+		bounds := frames[0].Bounds()
+
+		var buf bytes.Buffer
+		buf.WriteString(rawMagic)
+		binary.Write(&buf, binary.LittleEndian, [3]uint16{uint16(bounds.Dx()), uint16(bounds.Dy()), uint16(n)})
+		buf.Write([]byte{byte(bpp), 0})
+		for _, f := range frames[:n] {
+			binary.Write(&buf, binary.LittleEndian, uint16(o.delay))
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					r, g, b, _ := f.At(x, y).RGBA()
+					if bpp == 2 {
+						px := uint16(r>>11)<<11 | uint16(g>>10)<<5 | uint16(b>>11)
+						buf.Write([]byte{byte(px), byte(px >> 8)})
+					} else {
+						buf.Write([]byte{byte(r >> 8), byte(g >> 8), byte(b >> 8)})
+					}
+				}
+			}
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// encodePNG encodes the first frame.
+func encodePNG(o *output, maxDuration int, filters []encode.ImageFilter) ([]byte, error) {
+	frames := o.frames()
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames")
+	}
+	frames, err := filtered(frames[:1], filters)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = png.Encode(&buf, frames[0])
+	return buf.Bytes(), err
+}
+
+func filtered(frames []image.Image, filters []encode.ImageFilter) ([]image.Image, error) {
+	if len(filters) == 0 {
+		return frames, nil
+	}
+	out := make([]image.Image, 0, len(frames))
+	for _, f := range frames {
+		var err error
+		for _, filter := range filters {
+			f, err = filter(f)
+			if err != nil {
+				return nil, err
+			}
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
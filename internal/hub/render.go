@@ -0,0 +1,97 @@
+package hub
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/joe714/pixelgw/internal/catalog"
+	"tidbyt.dev/pixlet/render"
+)
+
+var (
+	// Applets return no roots when they have nothing to show right now
+	errNoRoots    = errors.New("produced no roots")
+	errSlotMissed = errors.New("not ready for its slot")
+)
+
+// displayHints are what an applet asked for in its render.Root.
+type displayHints struct {
+	showFullAnimation bool
+	maxAge            time.Duration // How long the image may be reused, 0 to render every time
+}
+
+type renderResult struct {
+	gen      uint64
+	app      AppConfig
+	fallback bool
+	out      *output
+	ttl      time.Duration
+	hints    displayHints
+	expires  time.Time // Zero if the image can't be reused
+	err      error
+}
+
+// renderApplet runs an applet and encodes its output for each of
+// profiles. It runs outside the channel goroutine, so it must only touch
+// its arguments.
+func renderApplet(ctx context.Context, cat *catalog.Catalog, channel string, app *AppConfig, profiles []Profile) (*output, displayHints, error) {
+	var hints displayHints
+	if app.Image != nil {
+		return fixedOutput(app.Image), hints, nil
+	}
+	log.Printf("%v %v running\n", channel, app.Manifest.Name)
+	applet, err := cat.LoadApplet(app.Manifest)
+	if err != nil {
+		return nil, hints, fmt.Errorf("applet failed to load: %w", err)
+	}
+
+	// Starlark may not notice the context being cancelled (a tight loop,
+	// or a module that ignores it), so give up on the applet ourselves
+	// and leave it to finish in the background.
+	type runResult struct {
+		roots []render.Root
+		err   error
+	}
+	done := make(chan runResult, 1)
+	go func() {
+		roots, err := applet.RunWithConfig(ctx, app.Config)
+		done <- runResult{roots, err}
+	}()
+	var roots []render.Root
+	select {
+	case res := <-done:
+		roots, err = res.roots, res.err
+	case <-ctx.Done():
+		return nil, hints, fmt.Errorf("applet abandoned: %w", context.Cause(ctx))
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			err = fmt.Errorf("%w (%w)", context.Cause(ctx), err)
+		}
+		return nil, hints, fmt.Errorf("applet failed: %w", err)
+	}
+	if len(roots) < 1 {
+		return nil, hints, errNoRoots
+	}
+
+	// The frame delay is encoded into the image, and the loop length is
+	// read back from the default encoding when fitting the display time.
+	out := newOutput(roots)
+	hints.showFullAnimation = out.screens.ShowFullAnimation
+	hints.maxAge = time.Duration(out.screens.MaxAge) * time.Second
+	img, err := out.encode(DefaultProfile)
+	if err != nil {
+		return nil, hints, err
+	}
+	for _, p := range profiles {
+		if _, err := out.encode(p); err != nil {
+			return nil, hints, err
+		}
+	}
+	log.Printf("%v %v success (%v %x)\n", channel, app.Manifest.Name, len(img), md5.Sum(img))
+	return out, hints, nil
+}
@@ -0,0 +1,106 @@
+package hub
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/joe714/pixelgw/internal/durable"
+)
+
+// CapToken in a hello means the device can store a token it is sent.
+const CapToken = "token"
+
+// Subprotocols for devices that can't set an Authorization header. The
+// device offers protocolName and tokenProtocol followed by its token, and
+// the server selects protocolName.
+const (
+	protocolName  = "pixelgw"
+	tokenProtocol = "pixelgw-token."
+)
+
+// requestToken returns the device token presented with r, if any.
+func requestToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	for _, p := range websocket.Subprotocols(r) {
+		if token, ok := strings.CutPrefix(p, tokenProtocol); ok {
+			return token
+		}
+	}
+	return ""
+}
+
+// offerToken lets a session that has just enrolled, or been approved, be
+// issued the device's first token once it says it can store one. Other
+// sessions never are, so nobody who only knows a device's UUID can claim
+// it. Devices enrolled before tokens existed get one when an admin rotates
+// it.
+func (c *Client) offerToken(h *Hub) {
+	c.tokenOffer.Store(true)
+	c.takeTokenOffer(h)
+}
+
+// takeTokenOffer issues the offered token if the device has said hello
+// with the token capability.
+func (c *Client) takeTokenOffer(h *Hub) {
+	info, ok := c.Info()
+	if !ok || !slices.Contains(info.Capabilities, CapToken) {
+		return
+	}
+	if c.tokenOffer.CompareAndSwap(true, false) {
+		go h.issueToken(c)
+	}
+}
+
+// issueToken sends a device its first token. A device that already has
+// one is left alone.
+func (h *Hub) issueToken(client *Client) {
+	token, hash, err := durable.NewDeviceToken()
+	if err != nil {
+		log.Printf("%v cannot create token: %v\n", client, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	issued, err := h.store.SetDeviceToken(ctx, client.UUID, hash, true)
+	if err != nil {
+		log.Printf("%v cannot store token: %v\n", client, err)
+		return
+	}
+	if issued {
+		log.Printf("%v issued token\n", client)
+		client.authenticated.Store(true)
+		client.sendControl(&Message{Type: MsgToken, Token: token})
+	}
+}
+
+// SendToken sends a device's new token to its live sessions that presented
+// the previous one and can store it. Returns the number it was sent to.
+func (h *Hub) SendToken(deviceUUID uuid.UUID, token string) int {
+	var clients []*Client
+	_ = RunTask(h.tasks, func() error {
+		for cl := range h.clients {
+			if cl.UUID == deviceUUID && cl.authenticated.Load() {
+				clients = append(clients, cl)
+			}
+		}
+		return nil
+	})
+	sent := 0
+	for _, cl := range clients {
+		info, ok := cl.Info()
+		if ok && slices.Contains(info.Capabilities, CapToken) && cl.sendControl(&Message{Type: MsgToken, Token: token}) {
+			sent++
+		}
+	}
+	return sent
+}
@@ -0,0 +1,34 @@
+package hub
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// webpDuration returns the length of one loop of an animated WebP image,
+// or 0 if the image is not animated.
+func webpDuration(img []byte) time.Duration {
+	if len(img) < 12 || string(img[0:4]) != "RIFF" || string(img[8:12]) != "WEBP" {
+		return 0
+	}
+
+	var total time.Duration
+	for pos := 12; pos+8 <= len(img); {
+		fourcc := string(img[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(img[pos+4 : pos+8]))
+		data := pos + 8
+		if data+size > len(img) {
+			break
+		}
+		// ANMF frame header: X, Y, width, height, duration as 24 bit
+		// little endian values followed by a flags byte.
+		if fourcc == "ANMF" && size >= 16 {
+			d := img[data+12 : data+15]
+			ms := int(d[0]) | int(d[1])<<8 | int(d[2])<<16
+			total += time.Duration(ms) * time.Millisecond
+		}
+		// Chunks are padded to an even length
+		pos = data + size + size&1
+	}
+	return total
+}
@@ -0,0 +1,236 @@
+// Package schedule decides when a channel applet is in rotation, or
+// when a device is dimmed.
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule limits an applet to certain dates, days of the week and times
+// of day. Each list that is set must match: an applet with days and
+// windows only shows during the windows on those days.
+type Schedule struct {
+	// Days of the week, "mon" through "sun"
+	Days []string `json:"days,omitempty"`
+	// Times of day. Windows that end before they start run past midnight.
+	Windows []Window `json:"windows,omitempty"`
+	// Inclusive date ranges, YYYY-MM-DD
+	Dates []DateRange `json:"dates,omitempty"`
+}
+
+// Window is a time of day range. Start and End are either "HH:MM" or
+// "sunrise" / "sunset" with an optional offset such as "sunset-30m".
+type Window struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type DateRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Location is where a channel's devices are, for time zones and sun times.
+type Location struct {
+	TZ        *time.Location
+	Latitude  *float64
+	Longitude *float64
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+type timeOfDay struct {
+	sun    string // "sunrise", "sunset" or empty for a clock time
+	offset time.Duration
+}
+
+type compiledWindow struct {
+	start, end timeOfDay
+}
+
+type compiledRange struct {
+	from, to time.Time
+}
+
+// Compiled is a validated schedule bound to a location.
+type Compiled struct {
+	days    map[time.Weekday]bool
+	windows []compiledWindow
+	dates   []compiledRange
+	loc     Location
+}
+
+// Parse decodes and validates a JSON schedule.
+func Parse(data string) (*Schedule, error) {
+	var s Schedule
+	err := json.Unmarshal([]byte(data), &s)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.Compile(Location{})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// IsEmpty reports whether the schedule places no limits.
+func (s *Schedule) IsEmpty() bool {
+	return s == nil || (len(s.Days) == 0 && len(s.Windows) == 0 && len(s.Dates) == 0)
+}
+
+// UsesSun reports whether any window is relative to sunrise or sunset,
+// which needs a location with coordinates.
+func (s *Schedule) UsesSun() bool {
+	for _, w := range s.Windows {
+		if strings.HasPrefix(w.Start, "sun") || strings.HasPrefix(w.End, "sun") {
+			return true
+		}
+	}
+	return false
+}
+
+// Compile validates s and binds it to loc for evaluation.
+func (s *Schedule) Compile(loc Location) (*Compiled, error) {
+	if loc.TZ == nil {
+		loc.TZ = time.Local
+	}
+	c := Compiled{loc: loc}
+	if len(s.Days) > 0 {
+		c.days = make(map[time.Weekday]bool)
+		for _, d := range s.Days {
+			wd, ok := weekdays[strings.ToLower(d)]
+			if !ok {
+				return nil, fmt.Errorf("unknown day %q", d)
+			}
+			c.days[wd] = true
+		}
+	}
+	for _, w := range s.Windows {
+		start, err := parseTimeOfDay(w.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseTimeOfDay(w.End)
+		if err != nil {
+			return nil, err
+		}
+		c.windows = append(c.windows, compiledWindow{start, end})
+	}
+	for _, r := range s.Dates {
+		from, err := time.ParseInLocation(time.DateOnly, r.From, loc.TZ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", r.From)
+		}
+		to, err := time.ParseInLocation(time.DateOnly, r.To, loc.TZ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q", r.To)
+		}
+		if to.Before(from) {
+			return nil, fmt.Errorf("date range %v to %v is reversed", r.From, r.To)
+		}
+		c.dates = append(c.dates, compiledRange{from, to.AddDate(0, 0, 1)})
+	}
+	return &c, nil
+}
+
+func parseTimeOfDay(s string) (timeOfDay, error) {
+	for _, sun := range []string{"sunrise", "sunset"} {
+		rest, ok := strings.CutPrefix(s, sun)
+		if !ok {
+			continue
+		}
+		tod := timeOfDay{sun: sun}
+		if rest != "" {
+			off, err := time.ParseDuration(rest)
+			if err != nil || (rest[0] != '+' && rest[0] != '-') {
+				return tod, fmt.Errorf("invalid offset in %q", s)
+			}
+			tod.offset = off
+		}
+		return tod, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return timeOfDay{}, fmt.Errorf("invalid time %q", s)
+	}
+	return timeOfDay{offset: time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute}, nil
+}
+
+// resolve returns the time of day on the date of day, or false if it
+// needs sun times that aren't available.
+func (c *Compiled) resolve(tod timeOfDay, day time.Time) (time.Time, bool) {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	if tod.sun == "" {
+		return midnight.Add(tod.offset), true
+	}
+	if c.loc.Latitude == nil || c.loc.Longitude == nil {
+		return time.Time{}, false
+	}
+	rise, set, ok := sunTimes(day, *c.loc.Latitude, *c.loc.Longitude)
+	if !ok {
+		return time.Time{}, false
+	}
+	if tod.sun == "sunrise" {
+		return rise.Add(tod.offset), true
+	}
+	return set.Add(tod.offset), true
+}
+
+// Active reports whether t falls within the schedule.
+func (c *Compiled) Active(t time.Time) bool {
+	if c == nil {
+		return true
+	}
+	t = t.In(c.loc.TZ)
+
+	if len(c.dates) > 0 {
+		in := false
+		for _, r := range c.dates {
+			if !t.Before(r.from) && t.Before(r.to) {
+				in = true
+				break
+			}
+		}
+		if !in {
+			return false
+		}
+	}
+
+	if c.days != nil && !c.days[t.Weekday()] {
+		return false
+	}
+
+	if len(c.windows) == 0 {
+		return true
+	}
+	for _, w := range c.windows {
+		start, ok := c.resolve(w.start, t)
+		if !ok {
+			continue
+		}
+		end, ok := c.resolve(w.end, t)
+		if !ok {
+			continue
+		}
+		if end.After(start) {
+			if !t.Before(start) && t.Before(end) {
+				return true
+			}
+		} else if !t.Before(start) || t.Before(end) {
+			return true
+		}
+	}
+	return false
+}
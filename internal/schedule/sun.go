@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	unixEpochJD = 2440587.5
+	j2000       = 2451545.0
+)
+
+func toJulian(t time.Time) float64 {
+	return float64(t.Unix())/86400 + unixEpochJD
+}
+
+func fromJulian(j float64) time.Time {
+	return time.Unix(int64(math.Round((j-unixEpochJD)*86400)), 0)
+}
+
+func sin(deg float64) float64 { return math.Sin(deg * math.Pi / 180) }
+func cos(deg float64) float64 { return math.Cos(deg * math.Pi / 180) }
+
+// sunTimes returns sunrise and sunset on the calendar day of date, using
+// the sunrise equation. ok is false during polar day or night.
+func sunTimes(date time.Time, lat float64, lng float64) (rise time.Time, set time.Time, ok bool) {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location())
+	n := math.Round(toJulian(noon) - j2000 + 0.0008)
+
+	// Mean solar time, anomaly, equation of center and ecliptic longitude
+	jStar := n - lng/360
+	m := math.Mod(357.5291+0.98560028*jStar, 360)
+	c := 1.9148*sin(m) + 0.0200*sin(2*m) + 0.0003*sin(3*m)
+	lambda := math.Mod(m+c+180+102.9372, 360)
+	transit := j2000 + jStar + 0.0053*sin(m) - 0.0069*sin(2*lambda)
+
+	sinDecl := sin(lambda) * sin(23.4397)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	cosHour := (sin(-0.833) - sin(lat)*sinDecl) / (cos(lat) * cosDecl)
+	if cosHour < -1 || cosHour > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	hour := math.Acos(cosHour) * 180 / math.Pi
+
+	rise = fromJulian(transit - hour/360).In(date.Location())
+	set = fromJulian(transit + hour/360).In(date.Location())
+	return rise, set, true
+}
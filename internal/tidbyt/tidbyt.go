@@ -0,0 +1,190 @@
+// Package tidbyt serves the subset of Tidbyt's cloud API used by pixlet
+// push and similar tooling, mapped onto pixelgw devices and channels.
+// Installations are the entries in the rotation of the device's channel.
+package tidbyt
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/joe714/pixelgw/internal/api"
+	"github.com/joe714/pixelgw/internal/durable"
+	"github.com/joe714/pixelgw/internal/errors"
+	"github.com/joe714/pixelgw/internal/hub"
+)
+
+type Handler struct {
+	hub   *hub.Hub
+	store *durable.Store
+}
+
+func NewHandler(hub *hub.Hub, store *durable.Store) *Handler {
+	return &Handler{hub: hub, store: store}
+}
+
+// Register adds the /v0 endpoints to mux.
+func (h *Handler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v0/devices/{id}/push", h.push)
+	mux.HandleFunc("GET /v0/devices/{id}/installations", h.listInstallations)
+	mux.HandleFunc("DELETE /v0/devices/{id}/installations/{installation}", h.deleteInstallation)
+}
+
+type pushRequest struct {
+	Image          []byte `json:"image"` // Base64 in JSON
+	InstallationID string `json:"installationID"`
+	Background     bool   `json:"background"`
+}
+
+type installation struct {
+	ID    string `json:"id"`
+	AppID string `json:"appID"`
+}
+
+type installationList struct {
+	Installations []installation `json:"installations"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// fail writes err with the same status the REST API would use.
+func fail(w http.ResponseWriter, err error) {
+	writeError(w, api.StatusCode(err), err)
+}
+
+// device looks up the device in the request path, writing an error
+// response if it doesn't exist.
+func (h *Handler) device(w http.ResponseWriter, r *http.Request) *durable.Device {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		fail(w, errors.DeviceNotFound)
+		return nil
+	}
+	d, err := h.store.GetDeviceByUUID(r.Context(), id)
+	if err != nil {
+		fail(w, err)
+		return nil
+	}
+	return d
+}
+
+// push shows an image on a device. With an installation ID the image is
+// also kept in the rotation of the device's channel, replacing any earlier
+// push with the same ID. Background pushes only update the rotation.
+func (h *Handler) push(w http.ResponseWriter, r *http.Request) {
+	d := h.device(w, r)
+	if d == nil {
+		return
+	}
+	var req pushRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Background && req.InstallationID == "" {
+		fail(w, errors.Wrap(errors.InvalidNotification, "background push requires an installationID"))
+		return
+	}
+	img, err := hub.NoticeImage(req.Image)
+	if err != nil {
+		fail(w, errors.Wrap(errors.InvalidImage, "%v", err))
+		return
+	}
+
+	if req.InstallationID != "" {
+		app, created, err := h.store.PutChannelImage(r.Context(), d.ChannelUUID, req.InstallationID, img, -1)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		if created {
+			h.hub.ReloadApplets(d.ChannelUUID, uuid.Nil)
+		} else {
+			h.hub.UpdateImage(d.ChannelUUID, app.UUID, img)
+		}
+	}
+
+	if !req.Background {
+		name := req.InstallationID
+		if name == "" {
+			name = "push"
+		}
+		shown := h.hub.NotifyDevice(d.UUID, hub.NewNotice(name, img, 0, 0, 1))
+		if !shown && req.InstallationID == "" {
+			fail(w, errors.DeviceNotConnected)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, struct{}{})
+}
+
+func (h *Handler) listInstallations(w http.ResponseWriter, r *http.Request) {
+	d := h.device(w, r)
+	if d == nil {
+		return
+	}
+	ch, err := h.store.GetChannelByUUID(r.Context(), d.ChannelUUID)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	resp := installationList{Installations: make([]installation, 0, len(ch.Applets))}
+	for _, app := range ch.Applets {
+		resp.Installations = append(resp.Installations, installation{
+			ID:    installationID(&app),
+			AppID: app.AppID,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) deleteInstallation(w http.ResponseWriter, r *http.Request) {
+	d := h.device(w, r)
+	if d == nil {
+		return
+	}
+	ch, err := h.store.GetChannelByUUID(r.Context(), d.ChannelUUID)
+	if err != nil {
+		fail(w, err)
+		return
+	}
+	id := r.PathValue("installation")
+	for _, app := range ch.Applets {
+		if installationID(&app) != id {
+			continue
+		}
+		log.Printf("Delete installation %v from %v\n", id, ch.Name)
+		err = h.store.DeleteChannelApplet(r.Context(), ch.UUID, app.UUID)
+		if err != nil {
+			fail(w, err)
+			return
+		}
+		h.hub.ReloadApplets(ch.UUID, uuid.Nil)
+		writeJSON(w, http.StatusOK, struct{}{})
+		return
+	}
+	fail(w, errors.AppletNotFound)
+}
+
+// installationID is the ID of a pushed image, or the UUID of an applet.
+func installationID(app *durable.ChannelApplet) string {
+	if app.InstallationID != nil {
+		return *app.InstallationID
+	}
+	return app.UUID.String()
+}